@@ -0,0 +1,98 @@
+package typeid
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// PrefixAliaser can be implemented by a [Prefix] to accept legacy prefixes of renamed ID types.
+// Parsing functions like [FromString] accept IDs with an alias and normalise them to the canonical prefix.
+// Use [SetPrefixAliasHook] to observe, whether aliases are still in use.
+//
+// Aliases must be valid prefixes and must not overlap the canonical prefix or each other, e.g. user_old is no valid
+// alias of the prefix user, as it could not be told apart from the environment qualifiers of user IDs. Parsing IDs
+// of types with invalid aliases fails.
+//
+// Example:
+//
+//	var userPrefixAliases = []string{"usr"}
+//
+//	func (UserPrefix) PrefixAliases() []string {
+//	    return userPrefixAliases
+//	}
+type PrefixAliaser interface {
+	PrefixAliases() []string
+}
+
+// prefixAliasHook is the hook set with [SetPrefixAliasHook].
+var prefixAliasHook atomic.Pointer[func(prefix, alias string)]
+
+// SetPrefixAliasHook sets a hook, which is called with the canonical prefix and the alias whenever an ID
+// is successfully parsed with a legacy alias of its prefix, see [PrefixAliaser]. Use it to log or count the usage of aliases.
+// The hook must be safe for concurrent use. Passing nil removes the hook.
+//
+// Example:
+//
+//	typeid.SetPrefixAliasHook(func(prefix, alias string) {
+//	    aliasCounter.WithLabelValues(prefix, alias).Inc()
+//	})
+func SetPrefixAliasHook(hook func(prefix, alias string)) {
+	if hook == nil {
+		prefixAliasHook.Store(nil)
+		return
+	}
+	prefixAliasHook.Store(&hook)
+}
+
+func getPrefixAliases[P Prefix]() []string {
+	var prefix P
+	if a, ok := any(prefix).(PrefixAliaser); ok {
+		return a.PrefixAliases()
+	}
+	return nil
+}
+
+// ValidatePrefixAliases returns an error if an alias is no valid prefix or overlaps the canonical prefix or another
+// alias, as parsing IDs would then be ambiguous, see [PrefixAliaser]. Parsing IDs of a type with invalid aliases fails
+// with the same error.
+func ValidatePrefixAliases(prefix string, aliases []string) error {
+	return validatePrefixAliases(prefix, aliases)
+}
+
+func validatePrefixAliases(prefix string, aliases []string) error {
+	for i, alias := range aliases {
+		if alias == "" {
+			return fmt.Errorf("invalid prefix aliases of prefix %q: empty alias", prefix)
+		}
+		if err := validatePrefix(alias); err != nil {
+			return err
+		}
+		if alias == prefix || hasPrefix(alias, prefix) || hasPrefix(prefix, alias) {
+			return fmt.Errorf("invalid prefix aliases of prefix %q: alias %q overlaps the prefix", prefix, alias)
+		}
+		for _, other := range aliases[:i] {
+			if alias == other || hasPrefix(alias, other) || hasPrefix(other, alias) {
+				return fmt.Errorf("invalid prefix aliases of prefix %q: alias %q overlaps alias %q", prefix, alias, other)
+			}
+		}
+	}
+	return nil
+}
+
+// trimPrefixAlias removes a legacy alias of the prefix P from b. It returns the alias, which is passed to
+// [callPrefixAliasHook] once the ID was parsed successfully.
+func trimPrefixAlias[P Prefix](b []byte) ([]byte, string, bool) {
+	for _, alias := range getPrefixAliases[P]() {
+		if hasPrefix(b, alias) {
+			return b[len(alias)+1:], alias, true
+		}
+	}
+	return nil, "", false
+}
+
+// callPrefixAliasHook calls the hook set with [SetPrefixAliasHook], if any.
+func callPrefixAliasHook[P Prefix](alias string) {
+	if hook := prefixAliasHook.Load(); hook != nil {
+		(*hook)(getPrefix[P](), alias)
+	}
+}
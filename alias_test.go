@@ -0,0 +1,146 @@
+package typeid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type transactionPrefix struct{}
+
+func (transactionPrefix) Prefix() string {
+	return "transaction"
+}
+
+var transactionPrefixAliases = []string{"txn", "tx"}
+
+func (transactionPrefix) PrefixAliases() []string {
+	return transactionPrefixAliases
+}
+
+type TransactionID = Sortable[transactionPrefix]
+
+type overlappingAliasPrefix struct{}
+
+func (overlappingAliasPrefix) Prefix() string {
+	return "user"
+}
+
+func (overlappingAliasPrefix) PrefixAliases() []string {
+	return []string{"user_old"}
+}
+
+// TestPrefixAliases cannot run in parallel, as it sets the prefix alias hook of the process.
+func TestPrefixAliases(t *testing.T) {
+	type use struct{ prefix, alias string }
+	var uses []use
+	SetPrefixAliasHook(func(prefix, alias string) {
+		uses = append(uses, use{prefix, alias})
+	})
+	t.Cleanup(func() { SetPrefixAliasHook(nil) })
+
+	const canonical = "transaction_01hp1aybq6f6athhfcvp1j8fpt"
+	expected := Must(FromString[TransactionID](canonical))
+	if len(uses) != 0 {
+		t.Fatalf("expected no alias uses for the canonical prefix, got %v", uses)
+	}
+
+	for _, tt := range []struct {
+		name  string
+		parse func(s string) (TransactionID, error)
+	}{
+		{
+			name:  "FromString",
+			parse: FromString[TransactionID],
+		},
+		{
+			name: "UnmarshalText",
+			parse: func(s string) (id TransactionID, err error) {
+				err = id.UnmarshalText([]byte(s))
+				return id, err
+			},
+		},
+		{
+			name: "Scan",
+			parse: func(s string) (id TransactionID, err error) {
+				err = id.Scan(s)
+				return id, err
+			},
+		},
+		{
+			name: "ScanText",
+			parse: func(s string) (id TransactionID, err error) {
+				err = id.ScanText(pgtype.Text{String: s, Valid: true})
+				return id, err
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			uses = nil
+
+			for _, alias := range transactionPrefixAliases {
+				id, err := tt.parse(alias + "_01hp1aybq6f6athhfcvp1j8fpt")
+				if err != nil {
+					t.Fatalf("unexpected error:\n%+v", err)
+				}
+				if id != expected || id.String() != canonical {
+					t.Errorf("expected %s, got %s", canonical, id)
+				}
+			}
+
+			if len(uses) != 2 || uses[0] != (use{"transaction", "txn"}) || uses[1] != (use{"transaction", "tx"}) {
+				t.Errorf("expected the hook to be called for both aliases, got %v", uses)
+			}
+		})
+	}
+
+	t.Run("unknown alias", func(t *testing.T) {
+		uses = nil
+
+		if _, err := FromString[TransactionID]("trx_01hp1aybq6f6athhfcvp1j8fpt"); !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("expected ErrInvalidPrefix, got %v", err)
+		}
+		if len(uses) != 0 {
+			t.Errorf("expected no alias uses, got %v", uses)
+		}
+	})
+
+	t.Run("invalid suffix", func(t *testing.T) {
+		uses = nil
+
+		if _, err := FromString[TransactionID]("txn_01hp1aybq6f6athhfcvp1j8fp"); !errors.Is(err, ErrInvalidSuffix) {
+			t.Errorf("expected ErrInvalidSuffix, got %v", err)
+		}
+		if len(uses) != 0 {
+			t.Errorf("expected no alias uses for an invalid id, got %v", uses)
+		}
+	})
+}
+
+func TestValidatePrefixAliases(t *testing.T) {
+	t.Parallel()
+
+	for _, aliases := range [][]string{
+		{"user_old"},
+		{"user"},
+		{""},
+		{"Usr"},
+		{"usr", "usr_old"},
+		{"usr_old", "usr"},
+	} {
+		if err := validatePrefixAliases("user", aliases); err == nil {
+			t.Errorf("%q: expected an error", aliases)
+		}
+	}
+	if err := validatePrefixAliases("user", []string{"usr", "member"}); err != nil {
+		t.Errorf("unexpected error:\n%+v", err)
+	}
+	if err := validatePrefixAliases("user_v2", []string{"user"}); err == nil {
+		t.Errorf("expected an error for an alias overlapped by the prefix")
+	}
+
+	if _, err := FromString[Random[overlappingAliasPrefix]]("user_old_01HCJF4N2RER3R6SZHBPFENHVA"); err == nil {
+		t.Errorf("expected an error for ids of a type with overlapping aliases")
+	}
+}
@@ -24,6 +24,10 @@ const (
 	alphLow = "0123456789abcdefghjkmnpqrstvwxyz"
 )
 
+// MixedSplit is the number of leading characters encoded in uppercase by [EncodeMixed]. The remaining characters
+// are encoded in lowercase.
+const MixedSplit = 10
+
 // EncodeUpper encodes the src [16]byte into a base32 string with uppercase letters.
 func EncodeUpper(src [16]byte) string {
 	return Encode(src, alphUp)
@@ -44,6 +48,22 @@ func EncodeLowerTo(dst []byte, src [16]byte) {
 	EncodeTo(dst, src, alphLow)
 }
 
+// EncodeMixed encodes the src [16]byte into a base32 string with the first 10 characters in uppercase
+// and the remaining 16 characters in lowercase letters.
+func EncodeMixed(src [16]byte) string {
+	dst := make([]byte, 26)
+	EncodeMixedTo(dst, src)
+	return string(dst)
+}
+
+// EncodeMixedTo encodes the src [16]byte into a provided 26-byte buffer using the mixed-case letters of [EncodeMixed].
+func EncodeMixedTo(dst []byte, src [16]byte) {
+	EncodeTo(dst, src, alphLow)
+	for i := 0; i < MixedSplit; i++ {
+		dst[i] = alphUp[decLower[dst[i]]]
+	}
+}
+
 func EncodeTo(dst []byte, src [16]byte, alphabet string) {
 	// Optimized unrolled loop ahead.
 
@@ -89,6 +109,50 @@ func Encode(src [16]byte, alphabet string) string {
 	return string(dst)
 }
 
+// EncodedLen returns the length of the base32 encoding of n bytes of input.
+func EncodedLen(n int) int {
+	return (n*8 + 4) / 5
+}
+
+// DecodedLen returns the number of bytes encoded by a base32 string of length n.
+func DecodedLen(n int) int {
+	return n * 5 / 8
+}
+
+// EncodeBytes encodes src of arbitrary length into a base32 string with the given alphabet. Like [Encode], it treats src as
+// a big-endian number and pads it with leading zero bits, so that the encoding of 16 bytes is equal to the one of [Encode].
+//
+// Direct usage is discouraged. Use EncodeUpperBytes or EncodeLowerBytes instead.
+func EncodeBytes(src []byte, alphabet string) string {
+	dst := make([]byte, EncodedLen(len(src)))
+
+	var acc uint
+	// Start with the number of padding bits.
+	bits := uint(len(dst)*5 - len(src)*8)
+	j := 0
+	for _, b := range src {
+		acc = acc<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			dst[j] = alphabet[(acc>>bits)&31]
+			j++
+		}
+	}
+
+	return string(dst)
+}
+
+// EncodeUpperBytes encodes src of arbitrary length into a base32 string with uppercase letters.
+func EncodeUpperBytes(src []byte) string {
+	return EncodeBytes(src, alphUp)
+}
+
+// EncodeLowerBytes encodes src of arbitrary length into a base32 string with lowercase letters.
+func EncodeLowerBytes(src []byte) string {
+	return EncodeBytes(src, alphLow)
+}
+
 // We us byte index tables for O(1) lookups when unmarshaling.
 // We use 0xFF as sentinel value for invalid indexes.
 var (
@@ -162,19 +226,118 @@ func DecodeLower(s string) ([]byte, error) {
 	return Decode(s, decLower)
 }
 
+// DecodeBytes decodes a base32 string of arbitrary length encoded with [EncodeBytes]. The second argument is a index lookup table,
+// see [Decode]. It returns [ErrInvalidChar] if the padding bits of the input are not zero.
+//
+// Direct usage is discouraged. Use DecodeUpperBytes or DecodeLowerBytes instead.
+func DecodeBytes(s string, idxTable [256]byte) ([]byte, error) {
+	dst := make([]byte, DecodedLen(len(s)))
+	if EncodedLen(len(dst)) != len(s) {
+		return nil, ErrInvalidLength
+	}
+
+	var acc uint
+	var bits uint
+	pad := uint(len(s)*5 - len(dst)*8)
+	j := 0
+	for i := 0; i < len(s); i++ {
+		idx := idxTable[s[i]]
+		if idx == 0xFF {
+			return nil, ErrInvalidChar
+		}
+		if i == 0 && idx>>(5-pad) != 0 {
+			// The leading padding bits must be zero to avoid overflows.
+			return nil, ErrInvalidChar
+		}
+		acc = acc<<5 | uint(idx)
+		bits += 5
+		if i == 0 {
+			bits -= pad
+		}
+		if bits >= 8 {
+			bits -= 8
+			dst[j] = byte(acc >> bits)
+			j++
+		}
+	}
+
+	return dst, nil
+}
+
+// DecodeUpperBytes decodes a uppercase base32 string of arbitrary length.
+func DecodeUpperBytes(s string) ([]byte, error) {
+	return DecodeBytes(s, decUpper)
+}
+
+// DecodeLowerBytes decodes a lowercase base32 string of arbitrary length.
+func DecodeLowerBytes(s string) ([]byte, error) {
+	return DecodeBytes(s, decLower)
+}
+
+// DecodeMixed decodes a base32 string encoded with [EncodeMixed] into a 16-byte slice.
+func DecodeMixed(s string) ([]byte, error) {
+	var res [16]byte
+	if err := DecodeMixedTo(&res, []byte(s)); err != nil {
+		return nil, err
+	}
+	return res[:], nil
+}
+
+// DecodeUpperTo decodes a uppercase base32 byte slice into the provided 16-byte array without allocating.
+func DecodeUpperTo(dst *[16]byte, src []byte) error {
+	return DecodeTo(dst, src, &decUpper)
+}
+
+// DecodeLowerTo decodes a lowercase base32 byte slice into the provided 16-byte array without allocating.
+func DecodeLowerTo(dst *[16]byte, src []byte) error {
+	return DecodeTo(dst, src, &decLower)
+}
+
+// DecodeMixedTo decodes a base32 byte slice encoded with [EncodeMixed] into the provided 16-byte array without allocating.
+func DecodeMixedTo(dst *[16]byte, src []byte) error {
+	if len(src) != 26 {
+		return ErrInvalidLength
+	}
+
+	// Normalize the uppercase characters to decode the whole input using the lowercase table.
+	var buf [26]byte
+	copy(buf[:], src)
+	for i := 0; i < MixedSplit; i++ {
+		idx := decUpper[buf[i]]
+		if idx == 0xFF {
+			return ErrInvalidChar
+		}
+		buf[i] = alphLow[idx]
+	}
+
+	return DecodeTo(dst, buf[:], &decLower)
+}
+
 // Decode decodes a given base32 string into a 16-byte slice. The second argument is a index lookup table, that
 // must be 256 bytes long. If the table is shorter, the function will panic. It's the callers responsibility to
 // ensure the table is valid.
 //
 // Direct usage is discouraged. Use DecodeUpper or DecodeLower instead.
-//
-//nolint:gosec // G602 false positive: s length is validated and all indexes are fixed in this unrolled decoder.
 func Decode(s string, idxTable [256]byte) ([]byte, error) {
-	if len(s) != 26 {
-		return nil, ErrInvalidLength
+	var res [16]byte
+	if err := DecodeTo(&res, []byte(s), &idxTable); err != nil {
+		return nil, err
 	}
+	return res[:], nil
+}
 
-	val := []byte(s)
+// DecodeTo decodes the base32 byte slice src into dst using the given index lookup table, see [Decode].
+// Unlike [Decode], it neither copies the input nor allocates the result.
+//
+// Direct usage is discouraged. Use DecodeUpperTo or DecodeLowerTo instead.
+//
+//nolint:gosec // G602 false positive: src length is validated and all indexes are fixed in this unrolled decoder.
+func DecodeTo(dst *[16]byte, src []byte, idxTable *[256]byte) error {
+	if len(src) != 26 {
+		return ErrInvalidLength
+	}
+
+	val := src
 	// Check if all the characters are part of the expected base32 character set.
 	if idxTable[val[0]] == 0xFF ||
 		idxTable[val[1]] == 0xFF ||
@@ -202,10 +365,10 @@ func Decode(s string, idxTable [256]byte) ([]byte, error) {
 		idxTable[val[23]] == 0xFF ||
 		idxTable[val[24]] == 0xFF ||
 		idxTable[val[25]] == 0xFF {
-		return nil, ErrInvalidChar
+		return ErrInvalidChar
 	}
 
-	var res [16]byte
+	res := dst
 
 	res[0] = (idxTable[val[0]] << 5) | idxTable[val[1]]
 	res[1] = (idxTable[val[2]] << 3) | (idxTable[val[3]] >> 2)
@@ -224,5 +387,5 @@ func Decode(s string, idxTable [256]byte) ([]byte, error) {
 	res[14] = (idxTable[val[22]] << 7) | (idxTable[val[23]] << 2) | (idxTable[val[24]] >> 3)
 	res[15] = (idxTable[val[24]] << 5) | idxTable[val[25]]
 
-	return res[:], nil
+	return nil
 }
@@ -3,6 +3,8 @@ package base32
 import (
 	"crypto/rand"
 	"encoding/base32"
+	"errors"
+	"strings"
 	"testing"
 	"testing/quick"
 )
@@ -125,6 +127,140 @@ func TestEncodeDecodeProp(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeMixed(t *testing.T) {
+	t.Parallel()
+
+	f := func(input [16]byte) bool {
+		enc := EncodeMixed(input)
+		if strings.ToLower(enc[:10]) != strings.ToLower(EncodeLower(input)[:10]) || enc[10:] != EncodeLower(input)[10:] {
+			t.Errorf("mixed encoding must match the lowercase encoding except for the case of the first 10 characters, got %s", enc)
+		}
+		if enc[:10] != strings.ToUpper(enc[:10]) {
+			t.Errorf("first 10 characters must be uppercase, got %s", enc)
+		}
+		dec, err := DecodeMixed(enc)
+		if err != nil {
+			t.Errorf("decode: received unexpected error:\n%+v", err)
+		}
+		return input == [16]byte(dec)
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	for _, invalid := range []string{
+		"01hp1aybq6f6athhfcvp1j8fpt",
+		"01HP1AYBQ6F6ATHHFCVP1J8FPT",
+		"01HP1AYBQ6F6athhfcvp1j8fpT",
+	} {
+		if _, err := DecodeMixed(invalid); !errors.Is(err, ErrInvalidChar) {
+			t.Errorf("decoding %s: expected ErrInvalidChar, got %v", invalid, err)
+		}
+	}
+	if _, err := DecodeMixed("01HP1AYBQ6"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected ErrInvalidLength, got %v", err)
+	}
+}
+
+func TestDecodeTo(t *testing.T) {
+	t.Parallel()
+
+	f := func(input [16]byte) bool {
+		var upper, lower, mixed [16]byte
+		if err := DecodeUpperTo(&upper, []byte(EncodeUpper(input))); err != nil {
+			t.Errorf("decode: received unexpected error:\n%+v", err)
+		}
+		if err := DecodeLowerTo(&lower, []byte(EncodeLower(input))); err != nil {
+			t.Errorf("decode: received unexpected error:\n%+v", err)
+		}
+		if err := DecodeMixedTo(&mixed, []byte(EncodeMixed(input))); err != nil {
+			t.Errorf("decode: received unexpected error:\n%+v", err)
+		}
+		return input == upper && input == lower && input == mixed
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	var dst [16]byte
+	if err := DecodeLowerTo(&dst, []byte("01hp1aybq6")); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected ErrInvalidLength, got %v", err)
+	}
+	if err := DecodeLowerTo(&dst, []byte("01HP1AYBQ6F6ATHHFCVP1J8FPT")); !errors.Is(err, ErrInvalidChar) {
+		t.Errorf("expected ErrInvalidChar, got %v", err)
+	}
+}
+
+// TestDecodeTo_Allocs cannot run in parallel, as other tests would distort the number of allocations.
+func TestDecodeTo_Allocs(t *testing.T) {
+	var dst [16]byte
+	src := []byte(EncodeLower([16]byte{1, 2, 3}))
+	allocs := testing.AllocsPerRun(100, func() {
+		//nolint:errcheck // Only the allocations are of interest.
+		DecodeLowerTo(&dst, src)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestEncodeDecodeBytes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal to fixed-length encoding", func(t *testing.T) {
+		t.Parallel()
+
+		f := func(input [16]byte) bool {
+			return EncodeLower(input) == EncodeLowerBytes(input[:]) && EncodeUpper(input) == EncodeUpperBytes(input[:])
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		f := func(input []byte) bool {
+			enc := EncodeLowerBytes(input)
+			if EncodedLen(len(input)) != len(enc) {
+				t.Errorf("expected encoded length %d, got %d", EncodedLen(len(input)), len(enc))
+			}
+			dec, err := DecodeLowerBytes(enc)
+			if err != nil {
+				t.Errorf("decode: received unexpected error:\n%+v", err)
+			}
+			return string(input) == string(dec)
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		t.Parallel()
+
+		// 52 characters encode 32 bytes with 4 padding bits.
+		maxValue := "1" + strings.Repeat("z", 51)
+		if _, err := DecodeLowerBytes(maxValue); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+		if _, err := DecodeLowerBytes("2" + strings.Repeat("z", 51)); !errors.Is(err, ErrInvalidChar) {
+			t.Errorf("expected ErrInvalidChar, got %v", err)
+		}
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		t.Parallel()
+
+		// 3 characters cannot be the encoding of any number of bytes.
+		if _, err := DecodeLowerBytes("000"); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("expected ErrInvalidLength, got %v", err)
+		}
+	})
+}
+
 func TestAlphabetValidity(t *testing.T) {
 	for i := range alphUp {
 		if decUpper[alphUp[i]] == 0xFF {
@@ -83,6 +83,55 @@ func BenchmarkFromString(b *testing.B) {
 	})
 }
 
+func BenchmarkDecode(b *testing.B) {
+	b.Run("sumup/typeid", func(b *testing.B) {
+		b.Run("Random", func(b *testing.B) {
+			src := []byte(typeid.MustNew[RandomTestID]().String())
+			// Box the source once, database/sql passes it as any as well.
+			var scanSrc any = src
+
+			b.Run(benchDecode("FromBytes", src, func(id *RandomTestID, src []byte) (err error) {
+				*id, err = typeid.FromBytes[RandomTestID](src)
+				return err
+			}))
+			b.Run(benchDecode("UnmarshalText", src, (*RandomTestID).UnmarshalText))
+			b.Run(benchDecode("Scan", src, func(id *RandomTestID, _ []byte) error { return id.Scan(scanSrc) }))
+			b.Run(benchDecode("ScanBytes", src, (*RandomTestID).ScanBytes))
+		})
+		b.Run("Sortable", func(b *testing.B) {
+			src := []byte(typeid.MustNew[SortableTestID]().String())
+			var scanSrc any = src
+
+			b.Run(benchDecode("FromBytes", src, func(id *SortableTestID, src []byte) (err error) {
+				*id, err = typeid.FromBytes[SortableTestID](src)
+				return err
+			}))
+			b.Run(benchDecode("UnmarshalText", src, (*SortableTestID).UnmarshalText))
+			b.Run(benchDecode("Scan", src, func(id *SortableTestID, _ []byte) error { return id.Scan(scanSrc) }))
+			b.Run(benchDecode("ScanBytes", src, (*SortableTestID).ScanBytes))
+		})
+	})
+
+	b.Run("go.jetify.com/typeid", func(b *testing.B) {
+		src := []byte(jpTypeId.Must(jpTypeId.New[JetpackID]()).String())
+		b.Run(benchDecode("UnmarshalText", src, (*JetpackID).UnmarshalText))
+	})
+}
+
+// benchDecode benchmarks decoding an ID from a byte slice, as done by JSON decoders and database drivers.
+func benchDecode[T any](name string, src []byte, decode func(*T, []byte) error) (string, func(*testing.B)) {
+	return name, func(b *testing.B) {
+		var id T
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := decode(&id, src); err != nil {
+				b.Fatalf("unexpected error:\n%+v", err)
+			}
+		}
+	}
+}
+
 func benchStringRandom(n int) (string, func(*testing.B)) {
 	ids := makeSortableIDs(n)
 	return fmt.Sprintf("n=%d", n), func(b *testing.B) {
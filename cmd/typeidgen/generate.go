@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const header = "Code generated by typeidgen. DO NOT EDIT."
+
+var funcs = template.FuncMap{
+	"lowerFirst": lowerFirst,
+	"quote": func(s string) string {
+		return fmt.Sprintf("%q", s)
+	},
+	"join": strings.Join,
+}
+
+var declTmpl = template.Must(template.New("decl").Funcs(funcs).Parse(`// {{.Header}}
+
+package {{.Package}}
+
+import "github.com/sumup/typeid"
+{{range .Entities}}
+// {{.Name}}Prefix is the prefix of [{{.Name}}ID].
+type {{.Name}}Prefix struct{}
+
+func ({{.Name}}Prefix) Prefix() string {
+	return {{quote .Prefix}}
+}
+{{if .Aliases}}
+var {{lowerFirst .Name}}PrefixAliases = []string{ {{- range $i, $a := .Aliases}}{{if $i}}, {{end}}{{quote $a}}{{end -}} }
+
+func ({{.Name}}Prefix) PrefixAliases() []string {
+	return {{lowerFirst .Name}}PrefixAliases
+}
+{{end}}
+{{- if .Environments}}
+var {{lowerFirst .Name}}Environments = []string{ {{- range $i, $e := .Environments}}{{if $i}}, {{end}}{{quote $e}}{{end -}} }
+
+func ({{.Name}}Prefix) Environments() []string {
+	return {{lowerFirst .Name}}Environments
+}
+{{end}}
+// {{.Name}}ID is the {{.Kind}} ID type of {{.Name}} entities.
+type {{.Name}}ID = typeid.{{.Kind.TypeName}}[{{.Name}}Prefix]
+{{end}}`))
+
+var testTmpl = template.Must(template.New("test").Funcs(funcs).Parse(`// {{.Header}}
+
+package {{.Package}}
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/typeidtest"
+)
+{{range .Entities}}
+func Test{{.Name}}ID(t *testing.T) {
+	t.Parallel()
+{{if eq .Kind "deterministic"}}
+	id := typeid.Must(typeid.FromName[{{.Name}}ID]("typeidgen"))
+{{- else if eq .Kind "sharded"}}
+	id := typeid.Must(typeid.NewInShard[{{.Name}}ID](0))
+{{- else}}
+	id := typeid.MustNew[{{.Name}}ID]()
+{{- end}}
+	typeidtest.AssertPrefix(t, id, {{quote .Prefix}})
+	typeidtest.AssertValid(t, id)
+{{- if .Aliases}}
+
+	suffix := strings.TrimPrefix(id.String(), {{quote .Prefix}})
+	for _, alias := range {{lowerFirst .Name}}PrefixAliases {
+		parsed, err := typeid.FromString[{{.Name}}ID](alias + suffix)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if parsed != id {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	}
+{{- else}}
+
+	if _, err := typeid.FromString[{{.Name}}ID]("x" + strings.TrimPrefix(id.String(), {{quote .Prefix}})); err == nil {
+		t.Errorf("expected an error for an unknown prefix")
+	}
+{{- end}}
+}
+{{end}}`))
+
+var sqlTmpl = template.Must(template.New("sql").Funcs(funcs).Parse(`-- {{.Header}}
+{{range .Entities}}
+CREATE DOMAIN {{.Prefix}}_id AS TEXT
+    CHECK (VALUE ~ '{{.Pattern}}');
+{{end}}`))
+
+var graphqlTmpl = template.Must(template.New("graphql").Funcs(funcs).Parse(`# {{.Header}}
+{{range .Entities}}
+"""
+{{.Name}} ID, formatted as {{.Prefix}}_<suffix>.
+"""
+scalar {{.Name}}ID
+{{end}}`))
+
+// lowerFirst lowers the first word of an exported Go name, including initialisms, e.g. APIKey to apiKey.
+func lowerFirst(s string) string {
+	n := 1
+	for n < len(s) && unicode.IsUpper(rune(s[n])) && (n+1 == len(s) || unicode.IsUpper(rune(s[n+1]))) {
+		n++
+	}
+	return strings.ToLower(s[:n]) + s[n:]
+}
+
+type templateData struct {
+	Header   string
+	Package  string
+	Entities []entity
+}
+
+// generateDecls returns the Go declarations of the ID types of the schema.
+func generateDecls(s *schema, pkg string) ([]byte, error) {
+	return executeGo(declTmpl, s, pkg)
+}
+
+// generateTests returns Go tests for the ID types generated by [generateDecls].
+func generateTests(s *schema, pkg string) ([]byte, error) {
+	return executeGo(testTmpl, s, pkg)
+}
+
+// generateSQL returns PostgreSQL domain definitions for the text representation of the ID types of the schema.
+// The domains check the IDs with the same regular expression as [typeid.Pattern].
+func generateSQL(s *schema) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := sqlTmpl.Execute(&buf, templateData{Header: header, Entities: s.Entities}); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// generateGraphQL returns GraphQL scalar definitions for the ID types of the schema, which map to the generated types
+// with gqlgen, see [typeid.Random.MarshalGQLContext].
+func generateGraphQL(s *schema) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := graphqlTmpl.Execute(&buf, templateData{Header: header, Entities: s.Entities}); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func executeGo(tmpl *template.Template, s *schema, pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData{Header: header, Package: pkg, Entities: s.Entities}); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
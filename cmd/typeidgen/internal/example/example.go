@@ -0,0 +1,5 @@
+// Package example contains ID types generated by typeidgen from ids.yaml.
+// The generated files serve as golden files for the tests of typeidgen.
+package example
+
+//go:generate go run github.com/sumup/typeid/cmd/typeidgen -schema ids.yaml -out ids_gen.go -sql ids.sql -graphql ids.graphql
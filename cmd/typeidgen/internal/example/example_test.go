@@ -0,0 +1,30 @@
+package example
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sumup/typeid"
+)
+
+// TestSQLPatterns checks that the generated SQL domains check IDs with the same expressions as typeid.Pattern.
+func TestSQLPatterns(t *testing.T) {
+	t.Parallel()
+
+	sql, err := os.ReadFile("ids.sql")
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	for _, pattern := range []string{
+		typeid.Pattern[UserID](),
+		typeid.Pattern[TransactionID](),
+		typeid.Pattern[APIKeyID](),
+		typeid.Pattern[MerchantID](),
+		typeid.Pattern[PaymentID](),
+	} {
+		if !strings.Contains(string(sql), "VALUE ~ '"+pattern+"'") {
+			t.Errorf("expected a domain checking %s", pattern)
+		}
+	}
+}
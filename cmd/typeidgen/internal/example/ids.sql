@@ -0,0 +1,16 @@
+-- Code generated by typeidgen. DO NOT EDIT.
+
+CREATE DOMAIN user_id AS TEXT
+    CHECK (VALUE ~ '^(user|usr)_[0-7][0-9a-hjkmnp-tv-z]{25}$');
+
+CREATE DOMAIN transaction_id AS TEXT
+    CHECK (VALUE ~ '^(transaction|txn|tx)_[0-7][0-9a-hjkmnp-tv-z]{25}$');
+
+CREATE DOMAIN api_key_id AS TEXT
+    CHECK (VALUE ~ '^api_key_((test)_)?[0-7][0-9A-HJKMNP-TV-Z]{25}$');
+
+CREATE DOMAIN merchant_id AS TEXT
+    CHECK (VALUE ~ '^merchant_[0-7][0-9A-HJKMNP-TV-Z]{9}[0-9a-hjkmnp-tv-z]{16}$');
+
+CREATE DOMAIN payment_id AS TEXT
+    CHECK (VALUE ~ '^payment_[0-7][0-9a-hjkmnp-tv-z]{25}$');
@@ -0,0 +1,73 @@
+// Code generated by typeidgen. DO NOT EDIT.
+
+package example
+
+import "github.com/sumup/typeid"
+
+// UserPrefix is the prefix of [UserID].
+type UserPrefix struct{}
+
+func (UserPrefix) Prefix() string {
+	return "user"
+}
+
+var userPrefixAliases = []string{"usr"}
+
+func (UserPrefix) PrefixAliases() []string {
+	return userPrefixAliases
+}
+
+// UserID is the sortable ID type of User entities.
+type UserID = typeid.Sortable[UserPrefix]
+
+// TransactionPrefix is the prefix of [TransactionID].
+type TransactionPrefix struct{}
+
+func (TransactionPrefix) Prefix() string {
+	return "transaction"
+}
+
+var transactionPrefixAliases = []string{"txn", "tx"}
+
+func (TransactionPrefix) PrefixAliases() []string {
+	return transactionPrefixAliases
+}
+
+// TransactionID is the sortable ID type of Transaction entities.
+type TransactionID = typeid.Sortable[TransactionPrefix]
+
+// APIKeyPrefix is the prefix of [APIKeyID].
+type APIKeyPrefix struct{}
+
+func (APIKeyPrefix) Prefix() string {
+	return "api_key"
+}
+
+var apiKeyEnvironments = []string{"live", "test"}
+
+func (APIKeyPrefix) Environments() []string {
+	return apiKeyEnvironments
+}
+
+// APIKeyID is the random ID type of APIKey entities.
+type APIKeyID = typeid.Random[APIKeyPrefix]
+
+// MerchantPrefix is the prefix of [MerchantID].
+type MerchantPrefix struct{}
+
+func (MerchantPrefix) Prefix() string {
+	return "merchant"
+}
+
+// MerchantID is the deterministic ID type of Merchant entities.
+type MerchantID = typeid.Deterministic[MerchantPrefix]
+
+// PaymentPrefix is the prefix of [PaymentID].
+type PaymentPrefix struct{}
+
+func (PaymentPrefix) Prefix() string {
+	return "payment"
+}
+
+// PaymentID is the sharded ID type of Payment entities.
+type PaymentID = typeid.Sharded[PaymentPrefix]
@@ -0,0 +1,85 @@
+// Code generated by typeidgen. DO NOT EDIT.
+
+package example
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/typeidtest"
+)
+
+func TestUserID(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[UserID]()
+	typeidtest.AssertPrefix(t, id, "user")
+	typeidtest.AssertValid(t, id)
+
+	suffix := strings.TrimPrefix(id.String(), "user")
+	for _, alias := range userPrefixAliases {
+		parsed, err := typeid.FromString[UserID](alias + suffix)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if parsed != id {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	}
+}
+
+func TestTransactionID(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[TransactionID]()
+	typeidtest.AssertPrefix(t, id, "transaction")
+	typeidtest.AssertValid(t, id)
+
+	suffix := strings.TrimPrefix(id.String(), "transaction")
+	for _, alias := range transactionPrefixAliases {
+		parsed, err := typeid.FromString[TransactionID](alias + suffix)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if parsed != id {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	}
+}
+
+func TestAPIKeyID(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[APIKeyID]()
+	typeidtest.AssertPrefix(t, id, "api_key")
+	typeidtest.AssertValid(t, id)
+
+	if _, err := typeid.FromString[APIKeyID]("x" + strings.TrimPrefix(id.String(), "api_key")); err == nil {
+		t.Errorf("expected an error for an unknown prefix")
+	}
+}
+
+func TestMerchantID(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.Must(typeid.FromName[MerchantID]("typeidgen"))
+	typeidtest.AssertPrefix(t, id, "merchant")
+	typeidtest.AssertValid(t, id)
+
+	if _, err := typeid.FromString[MerchantID]("x" + strings.TrimPrefix(id.String(), "merchant")); err == nil {
+		t.Errorf("expected an error for an unknown prefix")
+	}
+}
+
+func TestPaymentID(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.Must(typeid.NewInShard[PaymentID](0))
+	typeidtest.AssertPrefix(t, id, "payment")
+	typeidtest.AssertValid(t, id)
+
+	if _, err := typeid.FromString[PaymentID]("x" + strings.TrimPrefix(id.String(), "payment")); err == nil {
+		t.Errorf("expected an error for an unknown prefix")
+	}
+}
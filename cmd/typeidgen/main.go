@@ -0,0 +1,116 @@
+// Command typeidgen generates ID type declarations from a schema file.
+//
+// The schema is a YAML or JSON file listing the entities to generate ID types for:
+//
+//	entities:
+//	  - name: User
+//	    prefix: user
+//	    kind: sortable
+//	    aliases: [usr]
+//	    environments: [live, test]
+//
+// For each entity, typeidgen declares a prefix type and an ID type, e.g. UserPrefix and UserID. The kind is one of
+// random, sortable, deterministic or sharded. Aliases are legacy prefixes, see [typeid.PrefixAliaser], and
+// environments qualify the IDs, see [typeid.EnvironmentQualifier].
+// Generation fails for invalid or duplicate prefixes.
+//
+// Besides the declarations, typeidgen writes tests validating the ID types and optionally the PostgreSQL domain
+// definitions for the text representation of the IDs, which check them with [typeid.Pattern], and GraphQL scalar
+// definitions.
+//
+// typeidgen is a separate module. Add it as a tool with go get -tool github.com/sumup/typeid/cmd/typeidgen and use it
+// with go generate:
+//
+//	//go:generate go tool typeidgen -schema ids.yaml -out ids_gen.go -sql ids.sql -graphql ids.graphql
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "typeidgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("typeidgen", flag.ContinueOnError)
+	schemaPath := fs.String("schema", "", "path of the YAML or JSON schema file")
+	out := fs.String("out", "typeid_gen.go", "path of the generated Go file")
+	pkg := fs.String("package", os.Getenv("GOPACKAGE"), "package name of the generated Go files, defaults to the package of go generate")
+	tests := fs.Bool("tests", true, "generate tests next to the generated Go file")
+	sqlOut := fs.String("sql", "", "path of the generated SQL file with domain definitions, none if empty")
+	graphqlOut := fs.String("graphql", "", "path of the generated GraphQL schema file with scalar definitions, none if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *schemaPath == "" {
+		return errors.New("missing -schema")
+	}
+	if *pkg == "" {
+		return errors.New("missing -package")
+	}
+
+	f, err := os.Open(*schemaPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := parseSchema(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *schemaPath, err)
+	}
+
+	decls, err := generateDecls(s, *pkg)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(*out, decls); err != nil {
+		return err
+	}
+
+	if *tests {
+		src, err := generateTests(s, *pkg)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(strings.TrimSuffix(*out, ".go")+"_test.go", src); err != nil {
+			return err
+		}
+	}
+
+	if *sqlOut != "" {
+		src, err := generateSQL(s)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(*sqlOut, src); err != nil {
+			return err
+		}
+	}
+
+	if *graphqlOut != "" {
+		src, err := generateGraphQL(s)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(*graphqlOut, src); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, src []byte) error {
+	//nolint:gosec // Generated files are meant to be readable.
+	return os.WriteFile(path, src, 0o644)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sumup/typeid"
+)
+
+// kind is the kind of a generated ID type.
+type kind string
+
+const (
+	kindRandom        kind = "random"
+	kindSortable      kind = "sortable"
+	kindDeterministic kind = "deterministic"
+	kindSharded       kind = "sharded"
+)
+
+// TypeName returns the name of the generic ID type of the kind in the typeid package.
+func (k kind) TypeName() string {
+	switch k {
+	case kindRandom:
+		return "Random"
+	case kindSortable:
+		return "Sortable"
+	case kindDeterministic:
+		return "Deterministic"
+	case kindSharded:
+		return "Sharded"
+	}
+	return ""
+}
+
+// typeidKind returns the kind of the typeid package.
+func (k kind) typeidKind() typeid.Kind {
+	switch k {
+	case kindRandom:
+		return typeid.KindRandom
+	case kindSortable:
+		return typeid.KindSortable
+	case kindDeterministic:
+		return typeid.KindDeterministic
+	case kindSharded:
+		return typeid.KindSharded
+	}
+	return typeid.KindUnknown
+}
+
+// schema is the list of entities to generate ID types for. As YAML is a superset of JSON, schema files may use either.
+type schema struct {
+	Entities []entity `yaml:"entities"`
+}
+
+// entity describes the ID type of a single entity.
+type entity struct {
+	// Name is the exported Go name of the entity, e.g. User for the types UserPrefix and UserID.
+	Name string `yaml:"name"`
+	// Prefix is the prefix of the ID type, e.g. user.
+	Prefix string `yaml:"prefix"`
+	// Kind is the kind of the ID type, see the kind constants.
+	Kind kind `yaml:"kind"`
+	// Aliases are legacy prefixes accepted when parsing IDs, see [typeid.PrefixAliaser].
+	Aliases []string `yaml:"aliases"`
+	// Environments are the environments of the IDs, see [typeid.EnvironmentQualifier].
+	Environments []string `yaml:"environments"`
+}
+
+// Pattern returns the regular expression matching the IDs of the entity, see [typeid.Pattern].
+func (e entity) Pattern() string {
+	return e.Kind.typeidKind().Pattern(e.Prefix, e.Aliases, e.Environments)
+}
+
+func parseSchema(r io.Reader) (*schema, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var s schema
+	if err := dec.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode schema: %w", err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *schema) validate() error {
+	if len(s.Entities) == 0 {
+		return errors.New("schema contains no entities")
+	}
+
+	names := make(map[string]bool, len(s.Entities))
+	// prefixes maps all prefixes and aliases to the name of their entity.
+	prefixes := make(map[string]string, len(s.Entities))
+	for i, e := range s.Entities {
+		if !token.IsIdentifier(e.Name) || !token.IsExported(e.Name) {
+			return fmt.Errorf("entity %d: invalid name %q, expected an exported Go identifier", i, e.Name)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("entity %s: duplicate name", e.Name)
+		}
+		names[e.Name] = true
+
+		if e.Kind.TypeName() == "" {
+			return fmt.Errorf("entity %s: invalid kind %q, expected one of %q, %q, %q or %q", e.Name, e.Kind, kindRandom, kindSortable, kindDeterministic, kindSharded)
+		}
+
+		for _, prefix := range append([]string{e.Prefix}, e.Aliases...) {
+			if prefix == "" {
+				return fmt.Errorf("entity %s: empty prefix", e.Name)
+			}
+			if err := typeid.ValidatePrefix(prefix); err != nil {
+				return fmt.Errorf("entity %s: %w", e.Name, err)
+			}
+			if other, ok := prefixes[prefix]; ok {
+				return fmt.Errorf("entity %s: duplicate prefix %q, already used by entity %s", e.Name, prefix, other)
+			}
+			prefixes[prefix] = e.Name
+		}
+
+		if err := typeid.ValidatePrefixAliases(e.Prefix, e.Aliases); err != nil {
+			return fmt.Errorf("entity %s: %w", e.Name, err)
+		}
+		if err := typeid.ValidateEnvironments(e.Prefix, e.Environments); err != nil {
+			return fmt.Errorf("entity %s: %w", e.Name, err)
+		}
+	}
+
+	// Qualified prefixes must not collide with the prefixes of other entities, which are only known now.
+	all := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		all = append(all, prefix)
+	}
+	for _, e := range s.Entities {
+		if err := typeid.ValidateQualifiers(e.Prefix, e.Aliases, e.Environments, all); err != nil {
+			return fmt.Errorf("entity %s: %w", e.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRun_Golden checks that the generated files of the example package are up to date.
+func TestRun_Golden(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	err := run([]string{
+		"-schema", filepath.Join("internal", "example", "ids.yaml"),
+		"-package", "example",
+		"-out", filepath.Join(dir, "ids_gen.go"),
+		"-sql", filepath.Join(dir, "ids.sql"),
+		"-graphql", filepath.Join(dir, "ids.graphql"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+
+	for _, name := range []string{"ids_gen.go", "ids_gen_test.go", "ids.sql", "ids.graphql"} {
+		actual, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		expected, err := os.ReadFile(filepath.Join("internal", "example", name))
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if string(expected) != string(actual) {
+			t.Errorf("%s is outdated, run go generate ./...:\n%s", name, actual)
+		}
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := parseSchema(strings.NewReader(`{"entities": [{"name": "User", "prefix": "user", "kind": "random", "aliases": ["usr"]}]}`))
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if len(s.Entities) != 1 || s.Entities[0].Prefix != "user" || s.Entities[0].Kind != kindRandom || s.Entities[0].Aliases[0] != "usr" {
+			t.Errorf("unexpected schema: %+v", s)
+		}
+	})
+
+	for _, tt := range []struct {
+		name     string
+		schema   string
+		expected string
+	}{
+		{
+			name:     "no entities",
+			schema:   `entities: []`,
+			expected: "no entities",
+		},
+		{
+			name:     "unknown field",
+			schema:   `entities: [{name: User, prefix: user, kind: random, alias: usr}]`,
+			expected: "field alias not found",
+		},
+		{
+			name:     "invalid name",
+			schema:   `entities: [{name: user, prefix: user, kind: random}]`,
+			expected: "invalid name",
+		},
+		{
+			name:     "duplicate name",
+			schema:   `entities: [{name: User, prefix: user, kind: random}, {name: User, prefix: account, kind: random}]`,
+			expected: "duplicate name",
+		},
+		{
+			name:     "invalid environment",
+			schema:   `entities: [{name: User, prefix: user, kind: random, environments: [live, Test]}]`,
+			expected: "invalid environment",
+		},
+		{
+			name:     "overlapping alias",
+			schema:   `entities: [{name: User, prefix: user, kind: random, aliases: [user_old]}]`,
+			expected: "alias \"user_old\" overlaps the prefix",
+		},
+		{
+			name: "colliding environment",
+			schema: `entities: [
+  {name: User, prefix: user, kind: random, environments: [live, test]},
+  {name: UserTest, prefix: user_test, kind: random},
+]`,
+			expected: "environment \"test\" of prefix \"user\" collides with prefix \"user_test\"",
+		},
+		{
+			name:     "invalid kind",
+			schema:   `entities: [{name: User, prefix: user, kind: uuid}]`,
+			expected: "invalid kind",
+		},
+		{
+			name:     "empty prefix",
+			schema:   `entities: [{name: User, kind: random}]`,
+			expected: "empty prefix",
+		},
+		{
+			name:     "invalid prefix",
+			schema:   `entities: [{name: User, prefix: User, kind: random}]`,
+			expected: "invalid prefix",
+		},
+		{
+			name:     "invalid alias",
+			schema:   `entities: [{name: User, prefix: user, kind: random, aliases: [usr1]}]`,
+			expected: "invalid prefix",
+		},
+		{
+			name:     "duplicate prefix",
+			schema:   `entities: [{name: User, prefix: user, kind: random}, {name: Account, prefix: user, kind: random}]`,
+			expected: "duplicate prefix",
+		},
+		{
+			name:     "alias of another prefix",
+			schema:   `entities: [{name: User, prefix: user, kind: random}, {name: Account, prefix: account, kind: random, aliases: [user]}]`,
+			expected: "duplicate prefix",
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseSchema(strings.NewReader(tc.schema))
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestLowerFirst(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]string{
+		"User":      "user",
+		"APIKey":    "apiKey",
+		"ID":        "id",
+		"HTTPProxy": "httpProxy",
+	} {
+		if actual := lowerFirst(name); actual != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package typeid
+
+import (
+	"bytes"
+	"cmp"
+)
+
+// Compare returns an integer comparing two IDs by their UUID bytes and, for equal UUIDs, by their environment,
+// see [EnvironmentQualifier]. The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+//
+// For [Sortable] IDs, the byte order is equal to their creation order, up to the millisecond precision of UUIDv7.
+// It is equal to the lexicographical order of their string representation as well, unless the IDs are qualified with
+// different environments, as the qualifier precedes the suffix.
+//
+// Example:
+//
+//	slices.SortFunc(orderIDs, typeid.Compare[OrderID])
+func Compare[T IDType[P], P Prefix](a, b T) int {
+	ua, ub := a.UUID(), b.UUID()
+	if c := bytes.Compare(ua[:], ub[:]); c != 0 {
+		return c
+	}
+	return cmp.Compare(struct{ typedID[P] }(a).env, struct{ typedID[P] }(b).env)
+}
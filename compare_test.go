@@ -0,0 +1,80 @@
+package typeid
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/sumup/typeid/base32"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[UserID]()
+		if c := Compare(id, id); c != 0 {
+			t.Errorf("expected 0 comparing an id with itself, got %d", c)
+		}
+		if c := id.Compare(id); c != 0 {
+			t.Errorf("expected 0 comparing an id with itself, got %d", c)
+		}
+	})
+
+	t.Run("nil id sorts first", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[AccountID]()
+		if c := Compare(Nil[AccountID](), id); c != -1 {
+			t.Errorf("expected -1, got %d", c)
+		}
+		if c := id.Compare(Nil[AccountID]()); c != 1 {
+			t.Errorf("expected 1, got %d", c)
+		}
+	})
+
+	t.Run("sortable byte order equals string and creation order", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Date(2024, 2, 7, 8, 28, 55, 0, time.UTC)
+		created := make([]AccountID, 0, 100)
+		for i := range 100 {
+			u, err := uuid.NewV7AtTime(start.Add(time.Duration(i) * time.Millisecond))
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			created = append(created, Must(FromUUID[AccountID](u)))
+		}
+
+		shuffled := slices.Clone(created)
+		rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		byBytes := slices.Clone(shuffled)
+		slices.SortFunc(byBytes, AccountID.Compare)
+		if !slices.Equal(created, byBytes) {
+			t.Errorf("byte order does not match creation order")
+		}
+
+		byString := slices.Clone(shuffled)
+		slices.SortFunc(byString, func(a, b AccountID) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		if !slices.Equal(created, byString) {
+			t.Errorf("string order does not match creation order")
+		}
+
+		bySuffix := slices.Clone(shuffled)
+		slices.SortFunc(bySuffix, func(a, b AccountID) int {
+			return strings.Compare(base32.EncodeLower(a.UUID()), base32.EncodeLower(b.UUID()))
+		})
+		if !slices.Equal(created, bySuffix) {
+			t.Errorf("base32 order does not match creation order")
+		}
+	})
+}
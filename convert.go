@@ -0,0 +1,80 @@
+package typeid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKindMismatch is returned by [Retype] if the ID types are of different kinds, e.g. [Random] and [Sortable],
+	// or of [Sharded] kinds with different shard layouts.
+	ErrKindMismatch = errors.New("typeid kind mismatch")
+	// ErrVersionMismatch is returned by conversion functions if the UUID version does not match the kind of an ID type.
+	ErrVersionMismatch = errors.New("typeid UUID version mismatch")
+)
+
+// Retype converts an ID to another ID type of the same kind, changing only its prefix.
+// This is useful if two entities share an identity, e.g. a merchant and its account.
+// Unlike converting through [FromUUID], it fails with [ErrKindMismatch] if the ID types are of different kinds,
+// including [Sharded] IDs with different [ShardLayout], and with [ErrVersionMismatch] if the UUID version does not
+// match the kind.
+//
+// Example:
+//
+//	accountID, err := typeid.Retype[AccountID](merchantID)
+func Retype[To IDType[PT], From IDType[PF], PT Prefix, PF Prefix](id From) (To, error) {
+	// Each kind, including each shard layout, has its own processor.
+	to, from := To{}.processor(), From{}.processor()
+	if to != from {
+		return Nil[To](), fmt.Errorf("retype %T to %T: %w", id, To{}, ErrKindMismatch)
+	}
+	return convert[To](id, to)
+}
+
+// ConvertKind converts an ID to another kind of the same prefix, e.g. from [Random] to [Sortable].
+// The UUID is kept as is, therefore the conversion fails with [ErrVersionMismatch], unless the UUID version
+// matches the target kind, e.g. a [Random] ID holding a UUIDv7 that was generated by the database.
+//
+// Example:
+//
+//	sortableID, err := typeid.ConvertKind[SortableUserID](randomUserID)
+func ConvertKind[To IDType[P], From IDType[P], P Prefix](id From) (To, error) {
+	return convert[To](id, To{}.processor())
+}
+
+func convert[To IDType[PT], From IDType[PF], PT Prefix, PF Prefix](id From, to *processor) (To, error) {
+	if err := validatePrefix(getPrefix[PT]()); err != nil {
+		return Nil[To](), err
+	}
+
+	u := id.UUID()
+	if !u.IsNil() && u.Version() != to.version {
+		return Nil[To](), fmt.Errorf("convert %T to %T: %w: got UUIDv%d, expected UUIDv%d", id, To{}, ErrVersionMismatch, u.Version(), to.version)
+	}
+	if u.IsNil() {
+		return Nil[To](), nil
+	}
+	env, err := convertEnvironment[PT](struct{ typedID[PF] }(id).Environment())
+	if err != nil {
+		return Nil[To](), fmt.Errorf("convert %T to %T: %w", id, To{}, err)
+	}
+	return To{typedID[PT]{uuid: u, env: env}}, nil
+}
+
+// convertEnvironment returns the index of the environment env within the environments of the prefix P.
+// IDs without environment are converted to the current environment.
+func convertEnvironment[P Prefix](env string) (uint8, error) {
+	envs := getEnvironments[P]()
+	if len(envs) == 0 {
+		return 0, nil
+	}
+	if env == "" {
+		i, _, err := currentEnvironmentIndex[P]()
+		return i, err
+	}
+
+	if i, ok := environmentIndex(envs, env); ok {
+		return i, nil
+	}
+	return 0, fmt.Errorf("%w: environment %q is not one of %q of prefix %q", ErrEnvironmentMismatch, env, envs, getPrefix[P]())
+}
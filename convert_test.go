@@ -0,0 +1,109 @@
+package typeid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestRetype(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same kind", func(t *testing.T) {
+		t.Parallel()
+
+		accountID := MustNew[AccountID]()
+		orderID, err := Retype[OrderID](accountID)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if accountID.UUID() != orderID.UUID() {
+			t.Errorf("retyped id must keep the UUID, expected %s, got %s", accountID.UUID(), orderID.UUID())
+		}
+		if "order" != orderID.Type() {
+			t.Errorf("expected prefix order, got %s", orderID.Type())
+		}
+
+		nilID, err := Retype[OrderID](Nil[AccountID]())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !nilID.IsNil() {
+			t.Errorf("expected nil id, got %s", nilID)
+		}
+	})
+
+	t.Run("different kind", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Retype[OrderID](MustNew[UserID]()); !errors.Is(err, ErrKindMismatch) {
+			t.Errorf("expected ErrKindMismatch, got %v", err)
+		}
+	})
+
+	t.Run("different shard layout", func(t *testing.T) {
+		t.Parallel()
+
+		shardedID := Must(NewInShard[ShardedID](3))
+		if _, err := Retype[RegionID](shardedID); !errors.Is(err, ErrKindMismatch) {
+			t.Errorf("expected ErrKindMismatch, got %v", err)
+		}
+
+		orderID, err := Retype[Sharded[orderPrefix]](shardedID)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if ShardOf(orderID) != 3 {
+			t.Errorf("retyped id must keep the shard, got %d", ShardOf(orderID))
+		}
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		accountID := Must(FromUUID[AccountID](uuid.Must(uuid.NewV4())))
+		if _, err := Retype[OrderID](accountID); !errors.Is(err, ErrVersionMismatch) {
+			t.Errorf("expected ErrVersionMismatch, got %v", err)
+		}
+	})
+}
+
+func TestConvertKind(t *testing.T) {
+	t.Parallel()
+
+	type SortableUserID = Sortable[userPrefix]
+
+	t.Run("matching version", func(t *testing.T) {
+		t.Parallel()
+
+		// e.g. a UUIDv7 generated by the database.
+		randomID := Must(FromUUID[UserID](uuid.Must(uuid.NewV7())))
+		sortableID, err := ConvertKind[SortableUserID](randomID)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if randomID.UUID() != sortableID.UUID() {
+			t.Errorf("converted id must keep the UUID, expected %s, got %s", randomID.UUID(), sortableID.UUID())
+		}
+
+		back, err := ConvertKind[UserID](sortableID)
+		if !errors.Is(err, ErrVersionMismatch) {
+			t.Errorf("expected ErrVersionMismatch, got %v", err)
+		}
+		if !back.IsNil() {
+			t.Errorf("expected nil id on error, got %s", back)
+		}
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := ConvertKind[SortableUserID](MustNew[UserID]()); !errors.Is(err, ErrVersionMismatch) {
+			t.Errorf("expected ErrVersionMismatch, got %v", err)
+		}
+		if _, err := ConvertKind[UserID](MustNew[SortableUserID]()); !errors.Is(err, ErrVersionMismatch) {
+			t.Errorf("expected ErrVersionMismatch, got %v", err)
+		}
+	})
+}
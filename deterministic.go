@@ -0,0 +1,197 @@
+package typeid
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sumup/typeid/base32"
+)
+
+// Deterministic represents an unique identifier that is derived from a name, e.g. the key of an entity in an external system.
+// The same name always results in the same ID, which makes Deterministic IDs suitable for idempotent imports.
+// Internally, it's based on UUIDv5 (SHA-1), using a namespace per prefix (see [Namespacer]).
+//
+// The suffix part is encoded in crockford base32 with the first 10 characters in **uppercase** and the remaining 16 characters
+// in **lowercase**, e.g. merchant_6JJ0K5H4EPbgvv7hgnjmj3s0e8. This tells Deterministic IDs apart from [Random] and [Sortable] IDs.
+//
+// Deterministic IDs cannot be generated with [New], use [FromName] instead.
+type Deterministic[P Prefix] struct{ typedID[P] }
+
+// ErrNameBased is returned when trying to generate a [Deterministic] ID with [New].
+var ErrNameBased = errors.New("deterministic typeid must be created with typeid.FromName")
+
+// namespaceTypeID is the root namespace for the per prefix namespaces of [Deterministic] IDs.
+var namespaceTypeID = uuid.NewV5(uuid.NamespaceURL, "https://github.com/sumup/typeid")
+
+// Namespacer can be implemented by a [Prefix] to override the UUIDv5 namespace of [Deterministic] IDs.
+// By default, the namespace is derived from the prefix. Overriding the namespace allows keeping existing UUIDv5
+// identifiers when adopting this package.
+type Namespacer interface {
+	Namespace() uuid.UUID
+}
+
+var deterministicIDProc = &processor{
+	b32Encode: func(u uuid.UUID) string {
+		return base32.EncodeMixed([16]byte(u))
+	},
+	b32EncodeTo: func(dst []byte, u uuid.UUID) {
+		base32.EncodeMixedTo(dst, [16]byte(u))
+	},
+	b32Decode: func(b []byte) (uuid.UUID, error) {
+		var u uuid.UUID
+		if err := base32.DecodeMixedTo((*[16]byte)(&u), b); err != nil {
+			return uuid.Nil, err
+		}
+		return u, nil
+	},
+	generateUUID: func() (uuid.UUID, error) {
+		return uuid.Nil, ErrNameBased
+	},
+	version:       uuid.V5,
+	suffixPattern: mixedSuffixPattern,
+}
+
+// nameBased is a helper constraint for ID types derived from a name.
+type nameBased[P Prefix] interface {
+	instance[P]
+	namespace() uuid.UUID
+}
+
+// FromName returns the [Deterministic] ID of the specified type for the given name.
+//
+// Example:
+//
+//	type MerchantID = typeid.Deterministic[MerchantPrefix]
+//	id, err := typeid.FromName[MerchantID]("legacy-system:4711")
+func FromName[T nameBased[P], P Prefix](name string) (T, error) {
+	if err := checkPrefix[P](); err != nil {
+		return Nil[T](), err
+	}
+	tid, err := newTypedID[P](uuid.NewV5(T{}.namespace(), name))
+	if err != nil {
+		return Nil[T](), err
+	}
+	return T{tid}, nil
+}
+
+func getNamespace[P Prefix]() uuid.UUID {
+	var prefix P
+	if n, ok := any(prefix).(Namespacer); ok {
+		return n.Namespace()
+	}
+	return uuid.NewV5(namespaceTypeID, prefix.Prefix())
+}
+
+func (Deterministic[P]) processor() *processor {
+	return deterministicIDProc
+}
+
+func (Deterministic[P]) namespace() uuid.UUID {
+	return getNamespace[P]()
+}
+
+func (Deterministic[P]) Type() string {
+	return getPrefix[P]()
+}
+
+func (d Deterministic[P]) String() string {
+	return toString[P](d.typedID, d.processor())
+}
+
+func (d Deterministic[P]) UUID() uuid.UUID {
+	return d.uuid
+}
+
+// IsNil reports whether the ID is the nil ID, see [Nil].
+func (d Deterministic[P]) IsNil() bool {
+	return d.uuid.IsNil()
+}
+
+// IsZero reports whether the ID is the zero value, which is equal to the nil ID.
+// It allows omitting unset IDs with the `json:",omitzero"` struct tag.
+func (d Deterministic[P]) IsZero() bool {
+	return d.IsNil()
+}
+
+// Compare returns an integer comparing two IDs, see [Compare].
+// It can be used as a method expression, e.g. with [slices.SortFunc].
+func (d Deterministic[P]) Compare(other Deterministic[P]) int {
+	return Compare(d, other)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// Internally it use [Deterministic.String]
+func (d Deterministic[P]) MarshalText() ([]byte, error) {
+	return marshalText(d)
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// It parses a TypeID string using [FromString]
+func (d *Deterministic[P]) UnmarshalText(text []byte) error {
+	return unmarshalText(d, text)
+}
+
+func (d Deterministic[P]) Value() (driver.Value, error) {
+	return value(d)
+}
+
+func (d *Deterministic[P]) Scan(src any) error {
+	return scan(d, src)
+}
+
+func (d Deterministic[P]) TextValue() (pgtype.Text, error) {
+	return textValue(d)
+}
+
+func (d *Deterministic[P]) ScanText(v pgtype.Text) error {
+	return scanText(d, v)
+}
+
+// ScanBytes implements the [pgtype.BytesScanner] interface.
+func (d *Deterministic[P]) ScanBytes(v []byte) error {
+	return scanBytes(d, v)
+}
+
+// MarshalGQL implements the graphql.Marshaler interface of gqlgen, see https://gqlgen.com/reference/scalars/.
+// It writes the ID as string and panics with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+// gqlgen prefers [Deterministic.MarshalGQLContext], which returns the error instead.
+func (d Deterministic[P]) MarshalGQL(w io.Writer) {
+	mustMarshalGQL(d, w)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen. It accepts strings, which are passed
+// for both custom scalars and the built-in ID type.
+func (d *Deterministic[P]) UnmarshalGQL(v any) error {
+	return unmarshalGQL(d, v)
+}
+
+// MarshalGQLContext implements the graphql.ContextMarshaler interface of gqlgen. It writes the ID as string and
+// fails with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+func (d Deterministic[P]) MarshalGQLContext(_ context.Context, w io.Writer) error {
+	return marshalGQL(d, w)
+}
+
+// UnmarshalGQLContext implements the graphql.ContextUnmarshaler interface of gqlgen, see [Deterministic.UnmarshalGQL].
+func (d *Deterministic[P]) UnmarshalGQLContext(_ context.Context, v any) error {
+	return unmarshalGQL(d, v)
+}
+
+func (d Deterministic[P]) UUIDValue() (pgtype.UUID, error) {
+	return uuidValue(d)
+}
+
+func (d *Deterministic[P]) ScanUUID(v pgtype.UUID) error {
+	return scanUUID(d, v)
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+// IDs are logged in their string representation, see [NewSlogHandler] for other formats.
+func (d Deterministic[P]) LogValue() slog.Value {
+	return logValue(d)
+}
@@ -0,0 +1,93 @@
+package typeid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type merchantPrefix struct{}
+
+func (merchantPrefix) Prefix() string {
+	return "merchant"
+}
+
+type legacyPrefix struct{}
+
+func (legacyPrefix) Prefix() string {
+	return "legacy"
+}
+
+func (legacyPrefix) Namespace() uuid.UUID {
+	return uuid.NamespaceDNS
+}
+
+type MerchantID = Deterministic[merchantPrefix]
+
+func TestDeterministic_FromName(t *testing.T) {
+	t.Parallel()
+
+	id, err := FromName[MerchantID]("legacy-system:4711")
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if "merchant_6JJ0K5H4EPbgvv7hgnjmj3s0e8" != id.String() {
+		t.Errorf("deterministic id must be stable, got %s", id)
+	}
+	if uuid.V5 != id.UUID().Version() {
+		t.Errorf("expected UUIDv5, got version byte: %x", id.UUID().Version())
+	}
+	if id != Must(FromName[MerchantID]("legacy-system:4711")) {
+		t.Errorf("same name must result in the same id")
+	}
+	if id == Must(FromName[MerchantID]("legacy-system:4712")) {
+		t.Errorf("different names must result in different ids")
+	}
+
+	// The namespace is derived from the prefix, the same name yields different UUIDs for different prefixes.
+	userID := Must(FromName[Deterministic[userPrefix]]("legacy-system:4711"))
+	if id.UUID() == userID.UUID() {
+		t.Errorf("same name must result in different UUIDs for different prefixes")
+	}
+
+	legacyID := Must(FromName[Deterministic[legacyPrefix]]("example.com"))
+	if uuid.NewV5(uuid.NamespaceDNS, "example.com") != legacyID.UUID() {
+		t.Errorf("custom namespace must be used, got %s", legacyID.UUID())
+	}
+}
+
+func TestDeterministic_New(t *testing.T) {
+	t.Parallel()
+
+	id, err := New[MerchantID]()
+	if !errors.Is(err, ErrNameBased) {
+		t.Errorf("expected ErrNameBased, got %v", err)
+	}
+	if !id.IsNil() {
+		t.Errorf("expected nil id on error, got %s", id)
+	}
+}
+
+func TestDeterministic_FromString(t *testing.T) {
+	t.Parallel()
+
+	id := Must(FromName[MerchantID]("legacy-system:4711"))
+
+	parsed, err := FromString[MerchantID](id.String())
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if id != parsed {
+		t.Errorf("expected %s, got %s", id, parsed)
+	}
+
+	for _, invalid := range []string{
+		"merchant_6jj0k5h4epbgvv7hgnjmj3s0e8",
+		"merchant_6JJ0K5H4EPBGVV7HGNJMJ3S0E8",
+	} {
+		if _, err := FromString[MerchantID](invalid); !errors.Is(err, ErrParse) {
+			t.Errorf("parsing %s: expected ErrParse, got %v", invalid, err)
+		}
+	}
+}
@@ -9,6 +9,12 @@
 //     The suffix part is encoded in **lowercase** crockford base32.
 //   - [typeid.Random] is also based on UUIDv4 and is completely random. Unlike `typeid.Sortable`,
 //     the suffix part is encoded in **uppercase** crockford base32.
+//   - [typeid.Deterministic] is based on UUIDv5 and derived from a name, e.g. the key of an entity in an external system.
+//     The suffix part is encoded in crockford base32 with the first 10 characters in **uppercase** and the remaining ones in **lowercase**.
+//   - [typeid.Sharded] is based on UUIDv8 and is k-sortable like `typeid.Sortable`. Additionally, it contains the shard (or region)
+//     it was created in. The suffix part is encoded in **lowercase** crockford base32.
+//
+// For API keys and similar tokens, [typeid.Secret] provides prefixed secrets with 256 bits of entropy.
 //
 // Please refer to the respective type documentation for more details.
 //
@@ -20,6 +26,11 @@
 // When using pgx, both TEXT and UUID columns can be used directly. However, note that the type information is lost when using UUID columns, unless you take additional steps
 // at the database layer. Be mindful of your identifier semantics, especially in complex JOIN queries.
 //
+// # Logging
+//
+// ID types implement [log/slog.LogValuer] and are logged in their string representation. Use [NewSlogHandler] to log IDs
+// as a group of their prefix, UUID and creation time instead, or to redact sensitive ID types such as API keys.
+//
 // # Usage
 //
 // To create a new ID type, define a prefix type that implements the [Prefix] interface. Then, define a TypeAlias for your ID type to [Random] or [Sortable] with your
@@ -1,45 +1,121 @@
 package typeid
 
 import (
+	"database/sql/driver"
+	"errors"
 	"fmt"
 
-	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-// idImplementation is a helper constraint asserting the existence of the typeID methods on the type.
-type idImplementation[P Prefix] interface {
-	instance[P]
-	String() string
-	UUID() uuid.UUID
+// ErrNilID is returned when marshaling a nil ID of a type whose [Prefix] requires [NilMarshalError].
+var ErrNilID = errors.New("nil typeid")
+
+// NilMarshaling determines how nil IDs are marshaled to text, see [NilMarshaler].
+type NilMarshaling int
+
+const (
+	// NilMarshalDefault marshals nil IDs like any other ID, e.g. user_00000000000000000000000000.
+	NilMarshalDefault NilMarshaling = iota
+	// NilMarshalError makes MarshalText and Value fail with [ErrNilID] for nil IDs.
+	NilMarshalError
+	// NilMarshalEmpty marshals nil IDs to an empty string and stores them as NULL in databases.
+	// In turn, UnmarshalText parses an empty string and Scan a NULL value to the nil ID.
+	NilMarshalEmpty
+)
+
+// NilMarshaler can be implemented by a [Prefix] to change how nil IDs of the respective ID types are marshaled to text.
+// This prevents nil IDs from being persisted by accident.
+//
+// Example:
+//
+//	func (UserPrefix) NilMarshaling() typeid.NilMarshaling {
+//	    return typeid.NilMarshalError
+//	}
+type NilMarshaler interface {
+	NilMarshaling() NilMarshaling
+}
+
+func getNilMarshaling[P Prefix]() NilMarshaling {
+	var prefix P
+	if m, ok := any(prefix).(NilMarshaler); ok {
+		return m.NilMarshaling()
+	}
+	return NilMarshalDefault
 }
 
-func marshalText[T idImplementation[P], P Prefix](id T) ([]byte, error) {
+func marshalText[T IDType[P], P Prefix](id T) ([]byte, error) {
+	if id.UUID().IsNil() {
+		switch getNilMarshaling[P]() {
+		case NilMarshalError:
+			return nil, fmt.Errorf("marshal %T: %w", id, ErrNilID)
+		case NilMarshalEmpty:
+			return []byte{}, nil
+		case NilMarshalDefault:
+		}
+	}
 	return []byte(id.String()), nil
 }
 
-func unmarshalText[T idImplementation[P], P Prefix](dst *T, text []byte) error {
+func unmarshalText[T IDType[P], P Prefix](dst *T, text []byte) error {
 	var err error
-	*dst, err = FromString[T](string(text))
+
+	if len(text) == 0 && getNilMarshaling[P]() == NilMarshalEmpty {
+		*dst = Nil[T]()
+		return nil
+	}
+
+	*dst, err = FromBytes[T](text)
 	if err != nil {
 		return fmt.Errorf("unmarshal text to typeid.TypeID: %w", err)
 	}
 	return nil
 }
 
-func value[T idImplementation[P], P Prefix](id T) (string, error) {
+// nilValue applies the [NilMarshaling] of P to database values of the ID. It reports whether the ID is stored as NULL.
+func nilValue[T IDType[P], P Prefix](id T) (bool, error) {
+	if !id.UUID().IsNil() {
+		return false, nil
+	}
+	switch getNilMarshaling[P]() {
+	case NilMarshalError:
+		return false, fmt.Errorf("value %T: %w", id, ErrNilID)
+	case NilMarshalEmpty:
+		return true, nil
+	case NilMarshalDefault:
+	}
+	return false, nil
+}
+
+// scanNull returns the nil ID for NULL values, if P stores nil IDs as NULL.
+func scanNull[T IDType[P], P Prefix](dst *T) error {
+	if getNilMarshaling[P]() != NilMarshalEmpty {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+	*dst = Nil[T]()
+	return nil
+}
+
+func value[T IDType[P], P Prefix](id T) (driver.Value, error) {
+	if null, err := nilValue(id); null || err != nil {
+		return nil, err
+	}
 	return id.String(), nil
 }
 
-func scan[T idImplementation[P], P Prefix](dst *T, src any) error {
+func scan[T IDType[P], P Prefix](dst *T, src any) error {
 	var err error
 
-	s, ok := src.(string)
-	if !ok {
+	switch src := src.(type) {
+	case string:
+		*dst, err = FromString[T](src)
+	case []byte:
+		*dst, err = FromBytes[T](src)
+	case nil:
+		return scanNull(dst)
+	default:
 		return fmt.Errorf("scan typeid.Typeid: espected string, got %T", src)
 	}
-
-	*dst, err = FromString[T](s)
 	if err != nil {
 		return fmt.Errorf("scan typeid.TypeID: %w", err)
 	}
@@ -47,18 +123,21 @@ func scan[T idImplementation[P], P Prefix](dst *T, src any) error {
 	return nil
 }
 
-func textValue[T idImplementation[P], P Prefix](id T) (pgtype.Text, error) {
+func textValue[T IDType[P], P Prefix](id T) (pgtype.Text, error) {
+	if null, err := nilValue(id); null || err != nil {
+		return pgtype.Text{}, err
+	}
 	return pgtype.Text{
 		String: id.String(),
 		Valid:  true,
 	}, nil
 }
 
-func scanText[T idImplementation[P], P Prefix](dst *T, v pgtype.Text) error {
+func scanText[T IDType[P], P Prefix](dst *T, v pgtype.Text) error {
 	var err error
 
 	if !v.Valid {
-		return fmt.Errorf("cannot scan NULL into %T", dst)
+		return scanNull(dst)
 	}
 
 	*dst, err = FromString[T](v.String)
@@ -69,18 +148,36 @@ func scanText[T idImplementation[P], P Prefix](dst *T, v pgtype.Text) error {
 	return nil
 }
 
-func uuidValue[T idImplementation[P], P Prefix](id T) (pgtype.UUID, error) {
+func scanBytes[T IDType[P], P Prefix](dst *T, v []byte) error {
+	var err error
+
+	if v == nil {
+		return scanNull(dst)
+	}
+
+	*dst, err = FromBytes[T](v)
+	if err != nil {
+		return fmt.Errorf("scan text to typeid.TypeID: %w", err)
+	}
+
+	return nil
+}
+
+func uuidValue[T IDType[P], P Prefix](id T) (pgtype.UUID, error) {
+	if null, err := nilValue(id); null || err != nil {
+		return pgtype.UUID{}, err
+	}
 	return pgtype.UUID{
 		Bytes: id.UUID(),
 		Valid: true,
 	}, nil
 }
 
-func scanUUID[T idImplementation[P], P Prefix](dst *T, v pgtype.UUID) error {
+func scanUUID[T IDType[P], P Prefix](dst *T, v pgtype.UUID) error {
 	var err error
 
 	if !v.Valid {
-		return fmt.Errorf("cannot scan NULL into %T", dst)
+		return scanNull(dst)
 	}
 
 	*dst, err = FromUUIDBytes[T](v.Bytes[:])
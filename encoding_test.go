@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -154,6 +155,10 @@ func TestTypeID_SQL_Scan(t *testing.T) {
 			name:  "scan id string type",
 			input: str,
 		},
+		{
+			name:  "scan id bytes type",
+			input: []byte(str),
+		},
 		{
 			name:       "fail on invalid type prefix",
 			input:      otherPrefixID.String(),
@@ -230,3 +235,182 @@ func TestJSON(t *testing.T) {
 		t.Errorf("json decoding should return the original uuid string: expected %s, got %s", str, decoded.String())
 	}
 }
+
+type strictNilPrefix struct{}
+
+func (strictNilPrefix) Prefix() string {
+	return "strict"
+}
+
+func (strictNilPrefix) NilMarshaling() NilMarshaling {
+	return NilMarshalError
+}
+
+type emptyNilPrefix struct{}
+
+func (emptyNilPrefix) Prefix() string {
+	return "empty"
+}
+
+func (emptyNilPrefix) NilMarshaling() NilMarshaling {
+	return NilMarshalEmpty
+}
+
+func TestJSON_Nil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("omitzero", func(t *testing.T) {
+		t.Parallel()
+
+		type entity struct {
+			ID      UserID    `json:"id,omitzero"`
+			Account AccountID `json:"account,omitzero"`
+		}
+
+		encoded, err := json.Marshal(entity{Account: MustNew[AccountID]()})
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if strings.Contains(string(encoded), `"id"`) {
+			t.Errorf("nil id must be omitted, got %s", encoded)
+		}
+		if !strings.Contains(string(encoded), `"account"`) {
+			t.Errorf("non-nil id must not be omitted, got %s", encoded)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := json.Marshal(Nil[UserID]())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if `"user_`+emptyID+`"` != string(encoded) {
+			t.Errorf("expected nil id string, got %s", encoded)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := json.Marshal(Nil[Random[strictNilPrefix]]())
+		if !errors.Is(err, ErrNilID) {
+			t.Errorf("expected ErrNilID, got %v", err)
+		}
+
+		if _, err := json.Marshal(MustNew[Sortable[strictNilPrefix]]()); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := json.Marshal(Nil[Sortable[emptyNilPrefix]]())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if `""` != string(encoded) {
+			t.Errorf("expected empty string, got %s", encoded)
+		}
+
+		decoded := MustNew[Sortable[emptyNilPrefix]]()
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !decoded.IsNil() {
+			t.Errorf("empty string must decode to the nil id, got %s", decoded)
+		}
+
+		var other UserID
+		if err := json.Unmarshal(encoded, &other); err == nil {
+			t.Errorf("empty string must not decode for ids without NilMarshalEmpty")
+		}
+	})
+}
+
+func TestValue_Nil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := Nil[UserID]().Value()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if v != "user_"+emptyID {
+			t.Errorf("expected nil id string, got %v", v)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		id := Nil[Random[strictNilPrefix]]()
+		if _, err := id.Value(); !errors.Is(err, ErrNilID) {
+			t.Errorf("expected ErrNilID from Value, got %v", err)
+		}
+		if _, err := id.TextValue(); !errors.Is(err, ErrNilID) {
+			t.Errorf("expected ErrNilID from TextValue, got %v", err)
+		}
+		if _, err := id.UUIDValue(); !errors.Is(err, ErrNilID) {
+			t.Errorf("expected ErrNilID from UUIDValue, got %v", err)
+		}
+
+		var scanned Random[strictNilPrefix]
+		if err := scanned.Scan(nil); err == nil {
+			t.Errorf("NULL must not scan for ids without NilMarshalEmpty")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		id := Nil[Sortable[emptyNilPrefix]]()
+		v, err := id.Value()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if v != nil {
+			t.Errorf("expected NULL, got %v", v)
+		}
+		text, err := id.TextValue()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if text.Valid {
+			t.Errorf("expected NULL text, got %v", text)
+		}
+		u, err := id.UUIDValue()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if u.Valid {
+			t.Errorf("expected NULL UUID, got %v", u)
+		}
+
+		scanned := MustNew[Sortable[emptyNilPrefix]]()
+		if err := scanned.Scan(nil); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !scanned.IsNil() {
+			t.Errorf("NULL must scan to the nil id, got %s", scanned)
+		}
+		scanned = MustNew[Sortable[emptyNilPrefix]]()
+		if err := scanned.ScanText(pgtype.Text{}); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !scanned.IsNil() {
+			t.Errorf("NULL text must scan to the nil id, got %s", scanned)
+		}
+		scanned = MustNew[Sortable[emptyNilPrefix]]()
+		if err := scanned.ScanUUID(pgtype.UUID{}); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !scanned.IsNil() {
+			t.Errorf("NULL UUID must scan to the nil id, got %s", scanned)
+		}
+	})
+}
@@ -0,0 +1,315 @@
+package typeid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrEnvironmentMismatch is returned if an ID does not belong to the environment set with [SetEnvironment].
+var ErrEnvironmentMismatch = errors.New("typeid environment mismatch")
+
+// maxEnvironments is the maximum number of environments of an [EnvironmentQualifier].
+const maxEnvironments = 256
+
+// EnvironmentQualifier can be implemented by a [Prefix] to qualify IDs with the environment they were created in,
+// e.g. to tell sandbox IDs apart from production IDs, like Stripe's sk_test_ and sk_live_ keys.
+//
+// The first environment is the default one. Its IDs are formatted without qualifier, e.g. user_01hf98sp99fs2b4qf2jm11hse4,
+// which keeps IDs created before the introduction of environments valid. IDs of the other environments carry the
+// environment between prefix and suffix, e.g. user_test_01hf98sp99fs2b4qf2jm11hse4.
+// Environment names must match [a-z]+. Nil IDs have no environment, they are never qualified and parse in all
+// environments.
+//
+// A qualified prefix must not be the prefix or an alias of another ID type, e.g. the environment "test" of the
+// prefix "user" collides with the prefix "user_test", as user_test_01hf98sp99fs2b4qf2jm11hse4 would be an ID of
+// both types. As prefixes are not registered upfront, the collision is detected once the other ID type has been used
+// by the process: From then on, parsing qualified IDs and creating IDs in the environment fail with [ErrInvalidPrefix].
+// Use [ValidateQualifiers] to check the prefixes of all ID types upfront, e.g. in a test.
+//
+// Example:
+//
+//	var userEnvironments = []string{"live", "test"}
+//
+//	func (UserPrefix) Environments() []string {
+//	    return userEnvironments
+//	}
+type EnvironmentQualifier interface {
+	Environments() []string
+}
+
+// currentEnvironment is the environment set with [SetEnvironment].
+var currentEnvironment atomic.Pointer[string]
+
+// SetEnvironment sets the environment of the running process, e.g. "test" in a sandbox.
+// It is usually called once during startup.
+//
+// IDs of types with an [EnvironmentQualifier] are then created in this environment and parsing functions
+// like [FromString] fail with [ErrEnvironmentMismatch] for IDs of other environments. Creating or parsing
+// IDs of a type, which does not list the environment, fails as well.
+//
+// As long as no environment is set, IDs are created in the default environment and IDs of all environments
+// are accepted, use the Environment method of an ID to read its environment.
+func SetEnvironment(env string) {
+	currentEnvironment.Store(&env)
+}
+
+// NewIn is like [New], but creates the ID in the given environment instead of the one set with [SetEnvironment].
+// Use it in code handling several environments, e.g. a back office serving both sandbox and production data.
+// An empty environment is the default environment.
+//
+// Example:
+//
+//	userID, err := typeid.NewIn[UserID]("test")
+func NewIn[T instance[P], P Prefix](env string) (T, error) {
+	tid, err := generate[P](T{}.processor(), &env)
+	return T{tid}, err
+}
+
+// FromStringIn is like [FromString], but checks the environment of the ID against the given environment instead of
+// the one set with [SetEnvironment]. An empty environment accepts IDs of all environments.
+//
+// Example:
+//
+//	userID, err := typeid.FromStringIn[UserID]("test", "user_test_01hf98sp99fs2b4qf2jm11hse4")
+func FromStringIn[T instance[P], P Prefix](env, s string) (T, error) {
+	return fromBytes[T](unsafe.Slice(unsafe.StringData(s), len(s)), &env)
+}
+
+// FromUUIDIn is like [FromUUID], but returns the ID in the given environment instead of the one set with
+// [SetEnvironment]. As UUIDs carry no environment, use it to restore the environment of IDs stored as UUIDs.
+// An empty environment is the default environment.
+func FromUUIDIn[T instance[P], P Prefix](env string, u uuid.UUID) (T, error) {
+	return fromUUID[T](u, &env)
+}
+
+func getEnvironments[P Prefix]() []string {
+	var prefix P
+	if q, ok := any(prefix).(EnvironmentQualifier); ok {
+		return q.Environments()
+	}
+	return nil
+}
+
+// ValidateEnvironments returns an error if envs are no valid environments of the prefix, see [EnvironmentQualifier].
+// Generating or parsing IDs of a type with invalid environments fails with the same error.
+func ValidateEnvironments(prefix string, envs []string) error {
+	return validateEnvironments(prefix, envs)
+}
+
+func validateEnvironments(prefix string, envs []string) error {
+	if len(envs) > maxEnvironments {
+		return fmt.Errorf("invalid environments of prefix %q: got %d environments, expected <= %d", prefix, len(envs), maxEnvironments)
+	}
+
+	for i, env := range envs {
+		if env == "" {
+			return fmt.Errorf("invalid environments of prefix %q: empty environment", prefix)
+		}
+		for _, c := range env {
+			if c < 'a' || c > 'z' {
+				return fmt.Errorf("invalid environment: '%s', environment should match [a-z]+", env)
+			}
+		}
+		for _, other := range envs[:i] {
+			if env == other {
+				return fmt.Errorf("invalid environments of prefix %q: duplicate environment %q", prefix, env)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateQualifiers returns an error if qualifying the prefix or one of its aliases with one of the environments
+// results in one of the given prefixes of other ID types, see [EnvironmentQualifier].
+func ValidateQualifiers(prefix string, aliases, envs, prefixes []string) error {
+	others := make(map[string]bool, len(prefixes))
+	for _, other := range prefixes {
+		others[other] = true
+	}
+	for _, p := range append([]string{prefix}, aliases...) {
+		// The default environment is never qualified.
+		for i := 1; i < len(envs); i++ {
+			if qualified := p + "_" + envs[i]; others[qualified] {
+				return qualifierCollision(prefix, envs[i], qualified)
+			}
+		}
+	}
+	return nil
+}
+
+func qualifierCollision(prefix, env, qualified string) error {
+	return fmt.Errorf("%w: environment %q of prefix %q collides with prefix %q of another ID type", ErrInvalidPrefix, env, prefix, qualified)
+}
+
+var (
+	// knownPrefixesMu serializes updates of knownPrefixes.
+	knownPrefixesMu sync.Mutex
+	// knownPrefixes holds the set of prefixes and aliases of the ID types used by the process, see [checkPrefix].
+	// It is replaced on updates, so that lookups need no lock.
+	knownPrefixes atomic.Pointer[map[string]struct{}]
+)
+
+// registerPrefixes adds prefixes to the known prefixes.
+func registerPrefixes(prefixes ...string) {
+	knownPrefixesMu.Lock()
+	defer knownPrefixesMu.Unlock()
+
+	var known map[string]struct{}
+	if current := knownPrefixes.Load(); current != nil {
+		known = make(map[string]struct{}, len(*current)+len(prefixes))
+		for prefix := range *current {
+			known[prefix] = struct{}{}
+		}
+	} else {
+		known = make(map[string]struct{}, len(prefixes))
+	}
+	for _, prefix := range prefixes {
+		known[prefix] = struct{}{}
+	}
+	knownPrefixes.Store(&known)
+}
+
+// isKnownPrefix reports whether b is the prefix or an alias of an ID type used by the process.
+func isKnownPrefix(b []byte) bool {
+	known := knownPrefixes.Load()
+	if known == nil {
+		return false
+	}
+	_, ok := (*known)[string(b)]
+	return ok
+}
+
+// checkQualifier returns an error if the prefix qualified with the environment env is the prefix or an alias of
+// another ID type used by the process.
+func checkQualifier(prefix, env string) error {
+	if env == "" {
+		return nil
+	}
+	var buf [128]byte
+	qualified := append(append(append(buf[:0], prefix...), '_'), env...)
+	if isKnownPrefix(qualified) {
+		return qualifierCollision(prefix, env, string(qualified))
+	}
+	return nil
+}
+
+// currentEnvironmentIndex returns the index of the current environment within the environments of the prefix P.
+// It returns the default environment if no environment is set.
+func currentEnvironmentIndex[P Prefix]() (uint8, bool, error) {
+	return environmentIndexIn[P](currentEnvironment.Load())
+}
+
+// environmentIndexIn returns the index of env within the environments of the prefix P.
+// It returns the default environment if env is nil or empty.
+func environmentIndexIn[P Prefix](env *string) (uint8, bool, error) {
+	envs := getEnvironments[P]()
+	if len(envs) == 0 || env == nil || *env == "" {
+		return 0, false, nil
+	}
+
+	if i, ok := environmentIndex(envs, *env); ok {
+		return i, true, nil
+	}
+	return 0, false, fmt.Errorf("%w: environment %q is not one of %q of prefix %q", ErrEnvironmentMismatch, *env, envs, getPrefix[P]())
+}
+
+// environmentIndex returns the index of env within envs.
+func environmentIndex(envs []string, env string) (uint8, bool) {
+	for i := 0; i < len(envs) && i < maxEnvironments; i++ {
+		if envs[i] == env {
+			return uint8(i), true //nolint:gosec // The loop is bounded by maxEnvironments.
+		}
+	}
+	return 0, false
+}
+
+// newTypedID returns the ID of the given UUID in the current environment. Nil IDs have no environment.
+func newTypedID[P Prefix](u uuid.UUID) (typedID[P], error) {
+	return newTypedIDIn[P](u, currentEnvironment.Load())
+}
+
+// newTypedIDIn returns the ID of the given UUID in the environment env, see [environmentIndexIn].
+func newTypedIDIn[P Prefix](u uuid.UUID, env *string) (typedID[P], error) {
+	if err := checkPrefix[P](); err != nil {
+		return nilID[P](), err
+	}
+	if u.IsNil() {
+		return nilID[P](), nil
+	}
+
+	i, _, err := environmentIndexIn[P](env)
+	if err != nil {
+		return nilID[P](), err
+	}
+	tid := typedID[P]{uuid: u, env: i}
+	if err := checkQualifier(getPrefix[P](), tid.qualifier()); err != nil {
+		return nilID[P](), err
+	}
+	return tid, nil
+}
+
+// splitEnvironment splits the environment qualifier off the given suffix, see [checkEnvironment].
+func splitEnvironment[P Prefix](suffix []byte) (uint8, []byte, error) {
+	envs := getEnvironments[P]()
+	if len(envs) == 0 {
+		return 0, suffix, nil
+	}
+
+	var env uint8
+	if n := len(suffix) - suffixStrLen - 1; n > 0 && suffix[n] == '_' {
+		qualifier := suffix[:n]
+		var found bool
+		env, found = environmentIndex(envs, string(qualifier))
+		// The default environment is never qualified.
+		if !found || env == 0 {
+			return 0, nil, fmt.Errorf("%w: unknown environment %q of prefix %q", ErrInvalidPrefix, qualifier, getPrefix[P]())
+		}
+		suffix = suffix[n+1:]
+	}
+	return env, suffix, nil
+}
+
+// checkEnvironment checks the environment of a parsed ID against the environment env, see [environmentIndexIn].
+// Nil IDs have no environment and pass the check.
+func checkEnvironment[P Prefix](tid typedID[P], env *string) error {
+	if tid.uuid.IsNil() {
+		return nil
+	}
+
+	current, ok, err := environmentIndexIn[P](env)
+	if err != nil {
+		return err
+	}
+	if ok && tid.env != current {
+		envs := getEnvironments[P]()
+		return fmt.Errorf("%w: got environment %q, expected %q", ErrEnvironmentMismatch, envs[tid.env], envs[current])
+	}
+	return nil
+}
+
+// Environment returns the environment of the ID, if its [Prefix] implements [EnvironmentQualifier].
+// Otherwise, and for nil IDs, it returns an empty string.
+func (tid typedID[P]) Environment() string {
+	if tid.uuid.IsNil() {
+		return ""
+	}
+	envs := getEnvironments[P]()
+	if int(tid.env) >= len(envs) {
+		return ""
+	}
+	return envs[tid.env]
+}
+
+// qualifier returns the environment qualifier of the ID, which is empty for the default environment.
+func (tid typedID[P]) qualifier() string {
+	if tid.env == 0 {
+		return ""
+	}
+	return tid.Environment()
+}
@@ -0,0 +1,314 @@
+package typeid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type customerPrefix struct{}
+
+func (customerPrefix) Prefix() string {
+	return "customer"
+}
+
+var customerEnvironments = []string{"live", "test"}
+
+func (customerPrefix) Environments() []string {
+	return customerEnvironments
+}
+
+type CustomerID = Sortable[customerPrefix]
+
+type invalidEnvironmentsPrefix struct{}
+
+func (invalidEnvironmentsPrefix) Prefix() string {
+	return "vendor"
+}
+
+func (invalidEnvironmentsPrefix) Environments() []string {
+	return []string{"live", "Test", "live"}
+}
+
+func TestInvalidEnvironments(t *testing.T) {
+	t.Parallel()
+
+	type VendorID = Random[invalidEnvironmentsPrefix]
+	expected := ValidateEnvironments("vendor", invalidEnvironmentsPrefix{}.Environments())
+	if expected == nil {
+		t.Fatal("expected the environments to be invalid")
+	}
+
+	if _, err := New[VendorID](); err == nil || err.Error() != expected.Error() {
+		t.Errorf("expected %v from New, got %v", expected, err)
+	}
+	if _, err := FromString[VendorID]("vendor_01hp1aybq6f6athhfcvp1j8fpt"); err == nil || err.Error() != expected.Error() {
+		t.Errorf("expected %v from FromString, got %v", expected, err)
+	}
+	if _, err := FromUUID[VendorID](MustNew[UserID]().UUID()); err == nil || err.Error() != expected.Error() {
+		t.Errorf("expected %v from FromUUID, got %v", expected, err)
+	}
+}
+
+type tenantPrefix struct{}
+
+func (tenantPrefix) Prefix() string {
+	return "tenant"
+}
+
+func (tenantPrefix) Environments() []string {
+	return []string{"live", "test"}
+}
+
+type tenantTestPrefix struct{}
+
+func (tenantTestPrefix) Prefix() string {
+	return "tenant_test"
+}
+
+func TestQualifierCollision(t *testing.T) {
+	t.Parallel()
+
+	type (
+		TenantID     = Sortable[tenantPrefix]
+		TenantTestID = Sortable[tenantTestPrefix]
+	)
+
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+
+		if err := ValidateQualifiers("tenant", nil, []string{"live", "test"}, []string{"tenant_test"}); !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("expected ErrInvalidPrefix, got %v", err)
+		}
+		if err := ValidateQualifiers("tenant", []string{"org"}, []string{"live", "test"}, []string{"org_test"}); !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("expected ErrInvalidPrefix for an alias, got %v", err)
+		}
+		// The default environment is never qualified.
+		if err := ValidateQualifiers("tenant", nil, []string{"live", "test"}, []string{"tenant_live", "tenant"}); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+	})
+
+	t.Run("used prefix", func(t *testing.T) {
+		t.Parallel()
+
+		const s = "tenant_test_01hp1aybq6f6athhfcvp1j8fpt"
+		if _, err := FromString[TenantTestID](s); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if _, err := FromStringIn[TenantID]("", s); !errors.Is(err, ErrInvalidPrefix) || !errors.Is(err, ErrParse) {
+			t.Errorf("expected ErrInvalidPrefix for an ID of another type, got %v", err)
+		}
+		if _, err := NewIn[TenantID]("test"); !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("expected ErrInvalidPrefix for the colliding environment, got %v", err)
+		}
+		if _, err := NewIn[TenantID]("live"); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+	})
+}
+
+// TestEnvironment cannot run in parallel, as it changes the environment of the process.
+func TestEnvironment(t *testing.T) {
+	t.Cleanup(func() { SetEnvironment("") })
+
+	t.Run("default environment", func(t *testing.T) {
+		SetEnvironment("")
+
+		id := MustNew[CustomerID]()
+		if id.Environment() != "live" {
+			t.Errorf("expected environment live, got %q", id.Environment())
+		}
+		if !strings.HasPrefix(id.String(), "customer_") || len(id.String()) != len("customer_")+suffixStrLen {
+			t.Errorf("expected an unqualified id, got %s", id)
+		}
+
+		parsed, err := FromString[CustomerID](id.String())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != parsed {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	})
+
+	t.Run("qualified environment", func(t *testing.T) {
+		SetEnvironment("test")
+
+		id := MustNew[CustomerID]()
+		if id.Environment() != "test" {
+			t.Errorf("expected environment test, got %q", id.Environment())
+		}
+		if !strings.HasPrefix(id.String(), "customer_test_") {
+			t.Errorf("expected a qualified id, got %s", id)
+		}
+
+		parsed, err := FromString[CustomerID](id.String())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != parsed {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+
+		fromUUID := Must(FromUUID[CustomerID](id.UUID()))
+		if id != fromUUID {
+			t.Errorf("expected %s, got %s", id, fromUUID)
+		}
+	})
+
+	t.Run("enforced environment", func(t *testing.T) {
+		SetEnvironment("test")
+		testID := MustNew[CustomerID]()
+		SetEnvironment("live")
+		liveID := MustNew[CustomerID]()
+
+		if _, err := FromString[CustomerID](testID.String()); !errors.Is(err, ErrEnvironmentMismatch) || !errors.Is(err, ErrParse) {
+			t.Errorf("expected ErrEnvironmentMismatch, got %v", err)
+		}
+		if _, err := FromString[CustomerID](liveID.String()); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+
+		SetEnvironment("staging")
+		if _, err := New[CustomerID](); !errors.Is(err, ErrEnvironmentMismatch) {
+			t.Errorf("expected ErrEnvironmentMismatch, got %v", err)
+		}
+		if _, err := FromString[CustomerID](liveID.String()); !errors.Is(err, ErrEnvironmentMismatch) {
+			t.Errorf("expected ErrEnvironmentMismatch, got %v", err)
+		}
+		// ID types without environments are not affected.
+		if _, err := New[AccountID](); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+	})
+
+	t.Run("reported environment", func(t *testing.T) {
+		SetEnvironment("")
+
+		id, err := FromString[CustomerID]("customer_test_01hp1aybq6f6athhfcvp1j8fpt")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id.Environment() != "test" {
+			t.Errorf("expected environment test, got %q", id.Environment())
+		}
+		if id.String() != "customer_test_01hp1aybq6f6athhfcvp1j8fpt" {
+			t.Errorf("expected customer_test_01hp1aybq6f6athhfcvp1j8fpt, got %s", id)
+		}
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		SetEnvironment("")
+
+		for _, s := range []string{
+			"customer_staging_01hp1aybq6f6athhfcvp1j8fpt",
+			// The default environment is never qualified.
+			"customer_live_01hp1aybq6f6athhfcvp1j8fpt",
+		} {
+			if _, err := FromString[CustomerID](s); !errors.Is(err, ErrInvalidPrefix) {
+				t.Errorf("parsing %s: expected ErrInvalidPrefix, got %v", s, err)
+			}
+		}
+	})
+
+	t.Run("nil id", func(t *testing.T) {
+		SetEnvironment("test")
+
+		for _, id := range []CustomerID{Nil[CustomerID](), {}, Must(FromUUID[CustomerID](Nil[CustomerID]().UUID()))} {
+			if id.Environment() != "" {
+				t.Errorf("expected no environment, got %q", id.Environment())
+			}
+			if id.String() != "customer_"+emptyID {
+				t.Errorf("expected an unqualified nil id, got %s", id)
+			}
+
+			text, err := id.MarshalText()
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			var decoded CustomerID
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if decoded != Nil[CustomerID]() {
+				t.Errorf("expected the nil id, got %s", decoded)
+			}
+		}
+
+		qualified, err := FromString[CustomerID]("customer_test_" + emptyID)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if qualified != Nil[CustomerID]() {
+			t.Errorf("expected the nil id, got %s", qualified)
+		}
+	})
+
+	t.Run("explicit environment", func(t *testing.T) {
+		SetEnvironment("live")
+
+		id, err := NewIn[CustomerID]("test")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id.Environment() != "test" {
+			t.Errorf("expected environment test, got %q", id.Environment())
+		}
+
+		parsed, err := FromStringIn[CustomerID]("test", id.String())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != parsed {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+		if _, err := FromString[CustomerID](id.String()); !errors.Is(err, ErrEnvironmentMismatch) {
+			t.Errorf("expected ErrEnvironmentMismatch in the current environment, got %v", err)
+		}
+		if _, err := FromStringIn[CustomerID]("", id.String()); err != nil {
+			t.Errorf("an empty environment must accept all environments, got %v", err)
+		}
+
+		fromUUID, err := FromUUIDIn[CustomerID]("test", id.UUID())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != fromUUID {
+			t.Errorf("expected %s, got %s", id, fromUUID)
+		}
+
+		if _, err := NewIn[CustomerID]("staging"); !errors.Is(err, ErrEnvironmentMismatch) {
+			t.Errorf("expected ErrEnvironmentMismatch, got %v", err)
+		}
+	})
+
+	t.Run("compare environments", func(t *testing.T) {
+		SetEnvironment("")
+
+		testID := MustNew[CustomerID]()
+		testID = Must(FromUUIDIn[CustomerID]("test", testID.UUID()))
+		liveID := Must(FromUUIDIn[CustomerID]("live", testID.UUID()))
+		if testID == liveID {
+			t.Fatalf("ids of different environments must not be equal")
+		}
+		if Compare(liveID, testID) >= 0 || Compare(testID, liveID) <= 0 {
+			t.Errorf("ids with equal UUIDs must be ordered by environment")
+		}
+		if Compare(testID, testID) != 0 {
+			t.Errorf("expected equal ids to compare as 0")
+		}
+	})
+
+	t.Run("no environments", func(t *testing.T) {
+		SetEnvironment("test")
+
+		id := MustNew[AccountID]()
+		if id.Environment() != "" {
+			t.Errorf("expected no environment, got %q", id.Environment())
+		}
+		if _, err := FromString[AccountID](id.String()); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package typeid
+
+import (
+	"flag"
+	"strings"
+)
+
+// Flag defines a flag for an ID of the specified type with the given name and usage string on fs.
+// The return value is the address of an ID variable that stores the value of the flag.
+// The usage string is extended with the expected format of the ID, e.g. "(format: user_<suffix>)".
+//
+// Example:
+//
+//	userID := typeid.Flag[UserID](flag.CommandLine, "user", "user to delete")
+//	flag.Parse()
+func Flag[T IDType[P], P Prefix](fs *flag.FlagSet, name string, usage string) *T {
+	p := new(T)
+	FlagVar(fs, p, name, usage)
+	return p
+}
+
+// FlagVar defines a flag for an ID of the specified type with the given name and usage string on fs.
+// The argument p points to an ID variable in which to store the value of the flag.
+func FlagVar[T IDType[P], P Prefix](fs *flag.FlagSet, p *T, name string, usage string) {
+	fs.Var(&flagValue[T, P]{p: p}, name, flagUsage[P](usage))
+}
+
+// FlagSlice defines a repeatable flag for IDs of the specified type with the given name and usage string on fs.
+// The return value is the address of a slice that stores the values of all occurrences of the flag.
+//
+// Example:
+//
+//	userIDs := typeid.FlagSlice[UserID](flag.CommandLine, "user", "users to delete, can be repeated")
+//	flag.Parse()
+func FlagSlice[T IDType[P], P Prefix](fs *flag.FlagSet, name string, usage string) *[]T {
+	p := new([]T)
+	FlagSliceVar(fs, p, name, usage)
+	return p
+}
+
+// FlagSliceVar defines a repeatable flag for IDs of the specified type with the given name and usage string on fs.
+// The argument p points to a slice in which to store the values of all occurrences of the flag.
+func FlagSliceVar[T IDType[P], P Prefix](fs *flag.FlagSet, p *[]T, name string, usage string) {
+	fs.Var(&flagSliceValue[T, P]{p: p}, name, flagUsage[P](usage))
+}
+
+// flagUsage appends the expected format of IDs to the usage string. The format is back-quoted, so that
+// [flag.PrintDefaults] uses it as the name of the flag's argument.
+func flagUsage[P Prefix](usage string) string {
+	format := "<suffix>"
+	if prefix := getPrefix[P](); prefix != "" {
+		format = prefix + "_" + format
+	}
+	return usage + " (format: `" + format + "`)"
+}
+
+// flagValue implements the [flag.Getter] interface for IDs.
+type flagValue[T IDType[P], P Prefix] struct {
+	p *T
+}
+
+func (f *flagValue[T, P]) String() string {
+	// flag.PrintDefaults calls String on the zero value to determine the default value.
+	if f == nil || f.p == nil || (*f.p).UUID().IsNil() {
+		return ""
+	}
+	return (*f.p).String()
+}
+
+func (f *flagValue[T, P]) Set(s string) error {
+	id, err := FromString[T](s)
+	if err != nil {
+		return err
+	}
+	*f.p = id
+	return nil
+}
+
+func (f *flagValue[T, P]) Get() any {
+	return *f.p
+}
+
+// flagSliceValue implements the [flag.Getter] interface for repeatable ID flags.
+type flagSliceValue[T IDType[P], P Prefix] struct {
+	p *[]T
+}
+
+func (f *flagSliceValue[T, P]) String() string {
+	if f == nil || f.p == nil {
+		return ""
+	}
+	ids := make([]string, len(*f.p))
+	for i, id := range *f.p {
+		ids[i] = id.String()
+	}
+	return strings.Join(ids, ",")
+}
+
+func (f *flagSliceValue[T, P]) Set(s string) error {
+	id, err := FromString[T](s)
+	if err != nil {
+		return err
+	}
+	*f.p = append(*f.p, id)
+	return nil
+}
+
+func (f *flagSliceValue[T, P]) Get() any {
+	return *f.p
+}
@@ -0,0 +1,76 @@
+package typeid
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	t.Parallel()
+
+	userID := MustNew[UserID]()
+	accountIDs := []AccountID{MustNew[AccountID](), MustNew[AccountID]()}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	user := Flag[UserID](fs, "user", "user to delete")
+	accounts := FlagSlice[AccountID](fs, "account", "accounts to delete")
+
+	err := fs.Parse([]string{
+		"-user", userID.String(),
+		"-account", accountIDs[0].String(),
+		"-account", accountIDs[1].String(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if userID != *user {
+		t.Errorf("expected %s, got %s", userID, *user)
+	}
+	if len(*accounts) != 2 || accountIDs[0] != (*accounts)[0] || accountIDs[1] != (*accounts)[1] {
+		t.Errorf("expected %v, got %v", accountIDs, *accounts)
+	}
+
+	getter, ok := fs.Lookup("user").Value.(flag.Getter)
+	if !ok {
+		t.Fatalf("flag value must implement flag.Getter")
+	}
+	if userID != getter.Get() {
+		t.Errorf("expected %s, got %v", userID, getter.Get())
+	}
+}
+
+func TestFlag_Invalid(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var userID UserID
+	FlagVar(fs, &userID, "user", "user to delete")
+
+	if err := fs.Parse([]string{"-user", MustNew[AccountID]().String()}); err == nil {
+		t.Errorf("expected error for an id with another prefix")
+	}
+}
+
+func TestFlag_Usage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&buf)
+	Flag[UserID](fs, "user", "user to delete")
+	fs.PrintDefaults()
+
+	for _, expected := range []string{"-user user_<suffix>", "user to delete (format: user_<suffix>)"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("usage must contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "default") {
+		t.Errorf("usage must not show a default value, got:\n%s", buf.String())
+	}
+}
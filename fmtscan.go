@@ -0,0 +1,84 @@
+package typeid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ScanArg returns a [fmt.Scanner] storing the scanned ID in p, to read IDs with [fmt.Fscan] and related functions,
+// e.g. from whitespace-separated records. The ID types cannot implement [fmt.Scanner] themselves, as their Scan
+// method implements [database/sql.Scanner].
+//
+// The scanner reads a single token of letters, digits and underscores and parses it like [FromString], returning
+// the same errors. Tokens longer than any ID of the type are rejected without reading them entirely. At the end of
+// the input, the fmt functions report [io.ErrUnexpectedEOF], as for all implementations of [fmt.Scanner].
+//
+// Example:
+//
+//	var userID UserID
+//	var amount int
+//	_, err := fmt.Fscan(r, typeid.ScanArg(&userID), &amount)
+func ScanArg[T IDType[P], P Prefix](p *T) fmt.Scanner {
+	return &scanArg[T, P]{p: p, buf: make([]byte, 0, maxStrLen[P]())}
+}
+
+// scanArg implements the [fmt.Scanner] interface for IDs.
+type scanArg[T IDType[P], P Prefix] struct {
+	p *T
+	// buf holds the token, its capacity is the maximum length of IDs of the type.
+	buf []byte
+}
+
+func (a *scanArg[T, P]) Scan(state fmt.ScanState, verb rune) error {
+	if verb != 'v' && verb != 's' {
+		return fmt.Errorf("scan %T: unsupported verb %%%c", *a.p, verb)
+	}
+
+	state.SkipSpace()
+	tok := a.buf[:0]
+	for {
+		r, _, err := state.ReadRune()
+		if errors.Is(err, io.EOF) && len(tok) > 0 {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !isTokenRune(r) {
+			if err := state.UnreadRune(); err != nil {
+				return err
+			}
+			break
+		}
+		if len(tok) == cap(a.buf) {
+			return fmt.Errorf("%w: %w: token exceeds %d characters", ErrParse, ErrInvalidSuffix, cap(a.buf))
+		}
+		tok = append(tok, byte(r))
+	}
+
+	id, err := FromBytes[T](tok)
+	if err != nil {
+		return err
+	}
+	*a.p = id
+	return nil
+}
+
+// maxStrLen returns the maximum length of the string representation of IDs with the prefix P, including
+// aliases and environment qualifiers.
+func maxStrLen[P Prefix]() int {
+	prefixLen := len(getPrefix[P]())
+	for _, alias := range getPrefixAliases[P]() {
+		prefixLen = max(prefixLen, len(alias))
+	}
+	envLen := 0
+	for _, env := range getEnvironments[P]() {
+		envLen = max(envLen, len(env)+1)
+	}
+	return prefixLen + 1 + envLen + suffixStrLen
+}
+
+func isTokenRune(r rune) bool {
+	return r == '_' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
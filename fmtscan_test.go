@@ -0,0 +1,115 @@
+package typeid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestScanArg(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := MustNew[AccountID](), MustNew[AccountID]()
+		r := strings.NewReader(a.String() + " 10\n" + b.String() + "\t20\n")
+
+		var ids []AccountID
+		var amounts []int
+		for {
+			var id AccountID
+			var amount int
+			if _, err := fmt.Fscan(r, ScanArg(&id), &amount); err != nil {
+				if errors.Is(err, io.ErrUnexpectedEOF) {
+					break
+				}
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			ids = append(ids, id)
+			amounts = append(amounts, amount)
+		}
+
+		if len(ids) != 2 || ids[0] != a || ids[1] != b || amounts[0] != 10 || amounts[1] != 20 {
+			t.Errorf("expected [%s %s] [10 20], got %v %v", a, b, ids, amounts)
+		}
+	})
+
+	t.Run("sscanf", func(t *testing.T) {
+		t.Parallel()
+
+		userID := MustNew[UserID]()
+		var id UserID
+		var name string
+		if _, err := fmt.Sscanf("user="+userID.String()+" name=karl", "user=%v name=%s", ScanArg(&id), &name); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != userID || name != "karl" {
+			t.Errorf("expected %s karl, got %s %s", userID, id, name)
+		}
+	})
+
+	t.Run("aliases and environments", func(t *testing.T) {
+		t.Parallel()
+
+		transactionID := MustNew[TransactionID]()
+		customerSuffix := strings.TrimPrefix(MustNew[CustomerID]().String(), "customer_")
+
+		var id TransactionID
+		if _, err := fmt.Sscan("tx_"+strings.TrimPrefix(transactionID.String(), "transaction_"), ScanArg(&id)); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != transactionID {
+			t.Errorf("expected %s, got %s", transactionID, id)
+		}
+
+		var customerID CustomerID
+		if _, err := fmt.Sscan("customer_test_"+customerSuffix, ScanArg(&customerID)); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if customerID.Environment() != "test" {
+			t.Errorf("expected environment test, got %s", customerID.Environment())
+		}
+	})
+
+	for _, tt := range []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{name: "wrong prefix", input: MustNew[UserID]().String(), expectedErr: ErrInvalidPrefix},
+		{name: "short suffix", input: "system_account_01hf98sp99fs2b4qf2jm11hse", expectedErr: ErrInvalidSuffix},
+		{name: "overlong token", input: MustNew[AccountID]().String() + strings.Repeat("0", 100), expectedErr: ErrInvalidSuffix},
+		{name: "no token", input: "-", expectedErr: ErrInvalidPrefix},
+		{name: "empty", input: "", expectedErr: io.ErrUnexpectedEOF},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var id AccountID
+			if _, err := fmt.Sscan(tc.input, ScanArg(&id)); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestScanArg_Allocs(t *testing.T) {
+	r := strings.NewReader("")
+	s := MustNew[AccountID]().String()
+	var id AccountID
+	arg := ScanArg(&id)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Reset(s)
+		if _, err := fmt.Fscan(r, arg); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
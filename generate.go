@@ -15,15 +15,22 @@ type processor struct {
 	// b32EncodeTo applies a base32 encoding to a UUID and copies the result into a provided 26-byte buffer.
 	b32EncodeTo func([]byte, uuid.UUID)
 	// b32Decode decode a UUID using the resp. base32 decoding.
-	b32Decode func(string) (uuid.UUID, error)
+	b32Decode func([]byte) (uuid.UUID, error)
 	// Generates a new universal unique identifier.
 	generateUUID func() (uuid.UUID, error)
+	// version is the UUID version of the identifiers.
+	version byte
+	// validateUUID optionally rejects UUIDs that are invalid for the identifiers, both when decoding suffixes
+	// and in [FromUUID].
+	validateUUID func(uuid.UUID) error
+	// suffixPattern is a regular expression matching the suffixes accepted by b32Decode, see [Pattern].
+	suffixPattern string
 }
 
-func from[P Prefix](suffix string, p *processor) (typedID[P], error) {
+func from[P Prefix](suffix []byte, p *processor) (typedID[P], error) {
 	var err error
 
-	if err = validatePrefix(getPrefix[P]()); err != nil {
+	if err = checkPrefix[P](); err != nil {
 		return nilID[P](), err
 	}
 
@@ -35,20 +42,19 @@ func from[P Prefix](suffix string, p *processor) (typedID[P], error) {
 	return tid, nil
 }
 
-// generate generates
-func generate[P Prefix](p *processor) (typedID[P], error) {
+// generate generates a new ID in the environment env, see [environmentIndexIn].
+func generate[P Prefix](p *processor, env *string) (typedID[P], error) {
 	var err error
 
-	if err = validatePrefix(getPrefix[P]()); err != nil {
+	if err = checkPrefix[P](); err != nil {
 		return nilID[P](), err
 	}
 
-	tid := typedID[P]{}
-	tid.uuid, err = p.generateUUID()
+	u, err := p.generateUUID()
 	if err != nil {
 		return nilID[P](), err
 	}
-	return tid, nil
+	return newTypedIDIn[P](u, env)
 }
 
 func nilID[P Prefix]() typedID[P] {
@@ -57,6 +63,12 @@ func nilID[P Prefix]() typedID[P] {
 	}
 }
 
+// ValidatePrefix returns an error if prefix is not a valid [Prefix], i.e. does not match [a-z_]{0,63}.
+// Generating or parsing IDs of a type with an invalid prefix fails with the same error.
+func ValidatePrefix(prefix string) error {
+	return validatePrefix(prefix)
+}
+
 func validatePrefix(prefix string) error {
 	if prefix == "" {
 		return nil
@@ -76,7 +88,7 @@ func validatePrefix(prefix string) error {
 	return nil
 }
 
-func decodeSuffix(suffix string, p *processor) (uuid.UUID, error) {
+func decodeSuffix(suffix []byte, p *processor) (uuid.UUID, error) {
 	if len(suffix) != suffixStrLen {
 		return uuid.Nil, fmt.Errorf("invalid suffix: %s. Suffix length is %d, expected %d", suffix, len(suffix), suffixStrLen)
 	}
@@ -88,16 +100,23 @@ func decodeSuffix(suffix string, p *processor) (uuid.UUID, error) {
 	return p.b32Decode(suffix)
 }
 
-func toString[P Prefix](suffix uuid.UUID, p *processor) string {
+func toString[P Prefix](tid typedID[P], p *processor) string {
 	prefix := getPrefix[P]()
+	if env := tid.qualifier(); env != "" {
+		if prefix == "" {
+			prefix = env
+		} else {
+			prefix += "_" + env
+		}
+	}
 	if prefix == "" {
-		return p.b32Encode(suffix)
+		return p.b32Encode(tid.uuid)
 	}
 
 	buf := make([]byte, len(prefix)+1+suffixStrLen)
 	copy(buf, prefix)
 	copy(buf[len(prefix):], "_")
-	p.b32EncodeTo(buf[len(prefix)+1:], suffix)
+	p.b32EncodeTo(buf[len(prefix)+1:], tid.uuid)
 
 	return unsafe.String(unsafe.SliceData(buf), len(buf))
 }
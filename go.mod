@@ -4,5 +4,6 @@ go 1.24.0
 
 require (
 	github.com/gofrs/uuid/v5 v5.4.0
+	github.com/google/go-cmp v0.7.0
 	github.com/jackc/pgx/v5 v5.8.0
 )
@@ -0,0 +1,38 @@
+package typeid
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// marshalGQL writes the ID as GraphQL string. It fails with [ErrNilID] for nil IDs of prefixes requiring
+// [NilMarshalError].
+func marshalGQL[T IDType[P], P Prefix](id T, w io.Writer) error {
+	text, err := marshalText(id)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, strconv.Quote(string(text)))
+	return err
+}
+
+// mustMarshalGQL is marshalGQL for gqlgen's MarshalGQL, which cannot fail. It panics on failure, which gqlgen
+// recovers as error of the field, instead of writing an invalid value.
+func mustMarshalGQL[T IDType[P], P Prefix](id T, w io.Writer) {
+	if err := marshalGQL(id, w); err != nil {
+		panic(err)
+	}
+}
+
+// unmarshalGQL parses a GraphQL input value, which is a string for both custom scalars and the built-in ID type.
+func unmarshalGQL[T IDType[P], P Prefix](dst *T, v any) error {
+	switch v := v.(type) {
+	case string:
+		return unmarshalText(dst, []byte(v))
+	case []byte:
+		return unmarshalText(dst, v)
+	default:
+		return fmt.Errorf("unmarshal GraphQL value to %T: expected string, got %T", *dst, v)
+	}
+}
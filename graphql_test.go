@@ -0,0 +1,141 @@
+package typeid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTypeID_MarshalGQL(t *testing.T) {
+	t.Parallel()
+
+	userID := MustNew[UserID]()
+	accountID := MustNew[AccountID]()
+	for _, tt := range []struct {
+		name     string
+		id       interface{ MarshalGQL(io.Writer) }
+		expected string
+	}{
+		{name: "random", id: userID, expected: `"` + userID.String() + `"`},
+		{name: "sortable", id: accountID, expected: `"` + accountID.String() + `"`},
+		{name: "nil marshal empty", id: Nil[Sortable[emptyNilPrefix]](), expected: `""`},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var sb strings.Builder
+			tc.id.MarshalGQL(&sb)
+			if sb.String() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, sb.String())
+			}
+		})
+	}
+
+	t.Run("nil marshal error", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, ErrNilID) {
+				t.Errorf("expected a panic with ErrNilID, got %v", err)
+			}
+		}()
+		Nil[Random[strictNilPrefix]]().MarshalGQL(io.Discard)
+	})
+}
+
+func TestTypeID_MarshalGQLContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[AccountID]()
+		var sb strings.Builder
+		if err := id.MarshalGQLContext(context.Background(), &sb); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if expected := `"` + id.String() + `"`; sb.String() != expected {
+			t.Errorf("expected %s, got %s", expected, sb.String())
+		}
+
+		var decoded AccountID
+		if err := decoded.UnmarshalGQLContext(context.Background(), id.String()); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if decoded != id {
+			t.Errorf("expected %s, got %s", id, decoded)
+		}
+	})
+
+	t.Run("nil marshal error", func(t *testing.T) {
+		t.Parallel()
+
+		var sb strings.Builder
+		err := Nil[Deterministic[strictNilPrefix]]().MarshalGQLContext(context.Background(), &sb)
+		if !errors.Is(err, ErrNilID) {
+			t.Errorf("expected ErrNilID, got %v", err)
+		}
+		if sb.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %s", sb.String())
+		}
+	})
+}
+
+func TestTypeID_UnmarshalGQL(t *testing.T) {
+	t.Parallel()
+
+	userID := MustNew[UserID]()
+	accountID := MustNew[AccountID]()
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		var decoded UserID
+		if err := decoded.UnmarshalGQL(userID.String()); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if decoded != userID {
+			t.Errorf("expected %s, got %s", userID, decoded)
+		}
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		t.Parallel()
+
+		var decoded AccountID
+		if err := decoded.UnmarshalGQL([]byte(accountID.String())); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if decoded != accountID {
+			t.Errorf("expected %s, got %s", accountID, decoded)
+		}
+	})
+
+	t.Run("nil marshal empty", func(t *testing.T) {
+		t.Parallel()
+
+		decoded := MustNew[Sortable[emptyNilPrefix]]()
+		if err := decoded.UnmarshalGQL(""); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !decoded.IsNil() {
+			t.Errorf("expected the nil ID, got %s", decoded)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var decoded AccountID
+		if err := decoded.UnmarshalGQL(userID.String()); !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("expected ErrInvalidPrefix, got %v", err)
+		}
+		if err := decoded.UnmarshalGQL(42); err == nil {
+			t.Error("expected an error for an integer")
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package typeid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrMissingValue is returned by the request helpers if the request does not contain the value.
+var ErrMissingValue = errors.New("missing value")
+
+// RequestError is returned by the request helpers, e.g. [PathValue], if an ID cannot be extracted from a request.
+type RequestError struct {
+	// Source is the part of the request the ID was extracted from, one of "path", "query" or "header".
+	Source string
+	// Name is the name of the path wildcard, query parameter or header.
+	Name string
+	Err  error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s parameter %q: %s", e.Source, e.Name, e.Err.Error())
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// PathValue parses an ID of the specified type from the path wildcard with the given name, see [http.Request.PathValue].
+//
+// Example:
+//
+//	mux.HandleFunc("GET /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+//	    orderID, err := typeid.PathValue[OrderID](r, "id")
+//	    ...
+//	})
+func PathValue[T IDType[P], P Prefix](r *http.Request, name string) (T, error) {
+	return fromRequestValue[T]("path", name, r.PathValue(name))
+}
+
+// QueryValue parses an ID of the specified type from the first value of the query parameter with the given name.
+func QueryValue[T IDType[P], P Prefix](r *http.Request, name string) (T, error) {
+	return fromRequestValue[T]("query", name, r.URL.Query().Get(name))
+}
+
+// HeaderValue parses an ID of the specified type from the first value of the header with the given name.
+func HeaderValue[T IDType[P], P Prefix](r *http.Request, name string) (T, error) {
+	return fromRequestValue[T]("header", name, r.Header.Get(name))
+}
+
+func fromRequestValue[T IDType[P], P Prefix](source, name, s string) (T, error) {
+	if s == "" {
+		return Nil[T](), &RequestError{Source: source, Name: name, Err: ErrMissingValue}
+	}
+
+	id, err := FromString[T](s)
+	if err != nil {
+		return Nil[T](), &RequestError{Source: source, Name: name, Err: err}
+	}
+	return id, nil
+}
+
+type contextKey[T any] struct{}
+
+// NewContext returns a copy of ctx carrying the given ID. Use [FromContext] to retrieve it.
+func NewContext[T IDType[P], P Prefix](ctx context.Context, id T) context.Context {
+	return context.WithValue(ctx, contextKey[T]{}, id)
+}
+
+// FromContext returns the ID of the specified type stored in ctx, e.g. by [PathHandler].
+func FromContext[T IDType[P], P Prefix](ctx context.Context) (T, bool) {
+	id, ok := ctx.Value(contextKey[T]{}).(T)
+	if !ok {
+		return Nil[T](), false
+	}
+	return id, true
+}
+
+// PathHandler returns a handler that parses an ID of the specified type from the path wildcard with the given name
+// and stores it in the request context before calling next. Use [FromContext] to retrieve the ID.
+// If the ID cannot be parsed, it responds with an RFC 9457 problem details JSON object and does not call next.
+// The problem details only describe the failure in general terms, e.g. "invalid user id", as parse errors contain
+// the client input. Use [PathValue] and related functions directly to log the errors.
+//
+// Example:
+//
+//	mux.Handle("GET /orders/{id}", typeid.PathHandler[OrderID]("id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	    orderID, _ := typeid.FromContext[OrderID](r.Context())
+//	    ...
+//	})))
+func PathHandler[T IDType[P], P Prefix](name string, next http.Handler) http.Handler {
+	return requestValueHandler[T](PathValue[T], name, next)
+}
+
+// QueryHandler is like [PathHandler], but parses the ID from the query parameter with the given name.
+func QueryHandler[T IDType[P], P Prefix](name string, next http.Handler) http.Handler {
+	return requestValueHandler[T](QueryValue[T], name, next)
+}
+
+// HeaderHandler is like [PathHandler], but parses the ID from the header with the given name.
+func HeaderHandler[T IDType[P], P Prefix](name string, next http.Handler) http.Handler {
+	return requestValueHandler[T](HeaderValue[T], name, next)
+}
+
+func requestValueHandler[T IDType[P], P Prefix](
+	extract func(*http.Request, string) (T, error),
+	name string,
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := extract(r, name)
+		if err != nil {
+			writeProblem(w, err, Nil[T]().Type())
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// problem is an RFC 9457 problem details object.
+type problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Param is the name of the path wildcard, query parameter or header that failed to parse.
+	Param string `json:"param,omitempty"`
+}
+
+// writeProblem responds with the problem details of err, which is not exposed to the client.
+func writeProblem(w http.ResponseWriter, err error, prefix string) {
+	p := problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusBadRequest),
+		Status: http.StatusBadRequest,
+	}
+	name := "id"
+	if prefix != "" {
+		name = prefix + " id"
+	}
+
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		p.Param = reqErr.Name
+	}
+
+	switch {
+	case errors.Is(err, ErrMissingValue):
+		p.Title = "Missing ID"
+		p.Detail = "missing " + name
+	case errors.Is(err, ErrInvalidPrefix):
+		p.Title = "Invalid ID prefix"
+		p.Detail = "invalid " + name
+	case errors.Is(err, ErrParse):
+		p.Title = "Invalid ID"
+		p.Detail = "invalid " + name
+	default:
+		// Not a parse error, e.g. an invalid prefix definition of the ID type.
+		p.Title = http.StatusText(http.StatusInternalServerError)
+		p.Status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	//nolint:errcheck // Nothing left to do if the response cannot be written.
+	json.NewEncoder(w).Encode(p)
+}
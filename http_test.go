@@ -0,0 +1,146 @@
+package typeid
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestValues(t *testing.T) {
+	t.Parallel()
+
+	id := MustNew[UserID]()
+
+	t.Run("path", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/users/"+id.String(), nil)
+		r.SetPathValue("id", id.String())
+		parsed, err := PathValue[UserID](r, "id")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != parsed {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/users?user_id="+id.String(), nil)
+		parsed, err := QueryValue[UserID](r, "user_id")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != parsed {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	})
+
+	t.Run("header", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-User-Id", id.String())
+		parsed, err := HeaderValue[UserID](r, "X-User-Id")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != parsed {
+			t.Errorf("expected %s, got %s", id, parsed)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		_, err := QueryValue[UserID](r, "user_id")
+		if !errors.Is(err, ErrMissingValue) {
+			t.Errorf("expected ErrMissingValue, got %v", err)
+		}
+		var reqErr *RequestError
+		if !errors.As(err, &reqErr) || "query" != reqErr.Source || "user_id" != reqErr.Name {
+			t.Errorf("expected RequestError for query parameter user_id, got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		r := httptest.NewRequest(http.MethodGet, "/?user_id="+MustNew[AccountID]().String(), nil)
+		_, err := QueryValue[UserID](r, "user_id")
+		if !errors.Is(err, ErrParse) || !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("expected ErrParse and ErrInvalidPrefix, got %v", err)
+		}
+	})
+}
+
+func TestPathHandler(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}", PathHandler[UserID]("id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := FromContext[UserID](r.Context())
+		if !ok {
+			t.Errorf("id must be stored in the request context")
+		}
+		_, _ = w.Write([]byte(id.String()))
+	})))
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[UserID]()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/"+id.String(), nil))
+		if http.StatusOK != rec.Code {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if id.String() != rec.Body.String() {
+			t.Errorf("expected %s, got %s", id, rec.Body.String())
+		}
+	})
+
+	for _, tt := range []struct {
+		name  string
+		path  string
+		title string
+	}{
+		{
+			name:  "invalid prefix",
+			path:  "/users/" + MustNew[AccountID]().String(),
+			title: "Invalid ID prefix",
+		},
+		{
+			name:  "invalid suffix",
+			path:  "/users/user_01hf98sp99fs2b4qf2jm11hse4",
+			title: "Invalid ID",
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if http.StatusBadRequest != rec.Code {
+				t.Fatalf("expected status 400, got %d", rec.Code)
+			}
+			if "application/problem+json" != rec.Header().Get("Content-Type") {
+				t.Errorf("expected problem+json content type, got %s", rec.Header().Get("Content-Type"))
+			}
+
+			var p problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if tc.title != p.Title || http.StatusBadRequest != p.Status || "id" != p.Param || "invalid user id" != p.Detail {
+				t.Errorf("unexpected problem details: %+v", p)
+			}
+		})
+	}
+}
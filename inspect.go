@@ -0,0 +1,227 @@
+package typeid
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/sumup/typeid/base32"
+)
+
+// Kind is the kind of an ID detected by [Inspect].
+type Kind int
+
+const (
+	// KindUnknown is the kind of IDs, whose suffix does not match any ID type, e.g. nil IDs.
+	KindUnknown Kind = iota
+	// KindRandom is the kind of [Random] IDs.
+	KindRandom
+	// KindSortable is the kind of [Sortable] IDs.
+	KindSortable
+	// KindDeterministic is the kind of [Deterministic] IDs.
+	KindDeterministic
+	// KindSharded is the kind of [Sharded] IDs.
+	KindSharded
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindRandom:
+		return "random"
+	case KindSortable:
+		return "sortable"
+	case KindDeterministic:
+		return "deterministic"
+	case KindSharded:
+		return "sharded"
+	case KindUnknown:
+	}
+	return "unknown"
+}
+
+// Inspection describes an ID of unknown type, see [Inspect].
+type Inspection struct {
+	// Prefix is the prefix of the ID, including the qualifier of its environment, if any.
+	Prefix string
+	// Kind is the detected kind of the ID.
+	Kind Kind
+	// UUID is the decoded UUID of the ID.
+	UUID uuid.UUID
+	// Version is the version of the UUID.
+	Version byte
+	// Variant is the variant of the UUID, see [uuid.VariantRFC9562].
+	Variant byte
+	// Time is the creation time of sortable and sharded IDs. It is zero for other kinds.
+	Time time.Time
+	// Inconsistencies describes contradictions between the encoding of the suffix and its UUID, e.g. a lowercase
+	// suffix holding a UUIDv4. IDs with inconsistencies are of [KindUnknown].
+	Inconsistencies []string
+}
+
+// casing is the letter case of the suffixes of a kind.
+type casing int
+
+const (
+	casingUpper casing = iota
+	casingLower
+	// casingMixed is the casing of [base32.EncodeMixed], which encodes the first [base32.MixedSplit] characters in
+	// uppercase.
+	casingMixed
+)
+
+func (c casing) String() string {
+	switch c {
+	case casingUpper:
+		return "uppercase"
+	case casingLower:
+		return "lowercase"
+	case casingMixed:
+	}
+	return "mixed case"
+}
+
+// letters records the positions of the upper- and lowercase letters of a suffix.
+type letters struct {
+	upperHead, upperTail, lowerHead, lowerTail bool
+}
+
+func (l *letters) add(upper bool, i int) {
+	head := i < base32.MixedSplit
+	switch {
+	case upper && head:
+		l.upperHead = true
+	case upper:
+		l.upperTail = true
+	case head:
+		l.lowerHead = true
+	default:
+		l.lowerTail = true
+	}
+}
+
+// matches reports whether the letters are valid in suffixes of the given casing.
+func (l letters) matches(c casing) bool {
+	switch c {
+	case casingUpper:
+		return !l.lowerHead && !l.lowerTail
+	case casingLower:
+		return !l.upperHead && !l.upperTail
+	case casingMixed:
+	}
+	return !l.lowerHead && !l.upperTail
+}
+
+// String describes the casing of the letters.
+func (l letters) String() string {
+	for _, c := range []casing{casingLower, casingUpper, casingMixed} {
+		if l.matches(c) {
+			return c.String()
+		}
+	}
+	return "inconsistently cased"
+}
+
+// Inspect decodes an ID of unknown type and detects its kind from the casing of its suffix and the version of its
+// UUID, e.g. for debugging tools. Unlike [FromString], it does not need the ID type and accepts any valid prefix.
+// It returns an error wrapping [ErrParse], if s is no syntactically valid ID.
+//
+// Example:
+//
+//	info, err := typeid.Inspect("user_01hf98sp99fs2b4qf2jm11hse4")
+//	fmt.Println(info.Prefix, info.Kind, info.Time.UTC()) // user sortable 2023-11-15 10:35:27.913 +0000 UTC
+func Inspect(s string) (Inspection, error) {
+	var info Inspection
+	suffix := s
+	if i := strings.LastIndexByte(s, '_'); i >= 0 {
+		info.Prefix, suffix = s[:i], s[i+1:]
+		if err := validatePrefix(info.Prefix); err != nil {
+			return Inspection{}, fmt.Errorf("%w: %w", ErrParse, err)
+		}
+	}
+
+	if len(suffix) != suffixStrLen || suffix[0] > '7' {
+		return Inspection{}, fmt.Errorf("%w: %w %q", ErrParse, ErrInvalidSuffix, suffix)
+	}
+	var lower [suffixStrLen]byte
+	var l letters
+	for i := range suffixStrLen {
+		ch := suffix[i]
+		if ch >= 'A' && ch <= 'Z' {
+			ch += 'a' - 'A'
+			l.add(true, i)
+		} else if ch >= 'a' && ch <= 'z' {
+			l.add(false, i)
+		}
+		lower[i] = ch
+	}
+	if err := base32.DecodeLowerTo((*[16]byte)(&info.UUID), lower[:]); err != nil {
+		return Inspection{}, fmt.Errorf("%w: %w %q: %s", ErrParse, ErrInvalidSuffix, suffix, err.Error())
+	}
+
+	info.Version = info.UUID.Version()
+	info.Variant = info.UUID.Variant()
+	if info.UUID.IsNil() {
+		return info, nil
+	}
+
+	if info.Variant != uuid.VariantRFC9562 {
+		info.Inconsistencies = append(info.Inconsistencies, fmt.Sprintf("UUID variant %d is not the RFC 9562 variant", info.Variant))
+	}
+	info.Kind = kindOf(info.Version)
+	if info.Kind == KindUnknown {
+		info.Inconsistencies = append(info.Inconsistencies, fmt.Sprintf("UUID version %d is not used by any ID type", info.Version))
+	} else if expected := info.Kind.casing(); !l.matches(expected) {
+		info.Inconsistencies = append(info.Inconsistencies,
+			fmt.Sprintf("%s suffix holds a UUIDv%d, which %s IDs encode in %s", l, info.Version, info.Kind, expected))
+	}
+	if len(info.Inconsistencies) > 0 {
+		info.Kind = KindUnknown
+		return info, nil
+	}
+
+	if info.Kind == KindSortable || info.Kind == KindSharded {
+		// Both store the Unix timestamp in milliseconds in the first 48 bits.
+		u := info.UUID
+		ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+		info.Time = time.UnixMilli(ms)
+	}
+	return info, nil
+}
+
+// KindOf returns the kind of the ID type T.
+//
+// Example:
+//
+//	typeid.KindOf[UserID]() // KindSortable
+func KindOf[T instance[P], P Prefix]() Kind {
+	return kindOf((T{}).processor().version)
+}
+
+// kindOf returns the kind of IDs with the given UUID version.
+func kindOf(version byte) Kind {
+	switch version {
+	case uuid.V4:
+		return KindRandom
+	case uuid.V7:
+		return KindSortable
+	case uuid.V5:
+		return KindDeterministic
+	case versionSharded:
+		return KindSharded
+	}
+	return KindUnknown
+}
+
+// casing returns the casing of the suffixes of the kind.
+func (k Kind) casing() casing {
+	switch k {
+	case KindRandom:
+		return casingUpper
+	case KindDeterministic:
+		return casingMixed
+	case KindSortable, KindSharded, KindUnknown:
+	}
+	return casingLower
+}
@@ -0,0 +1,164 @@
+package typeid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/sumup/typeid/base32"
+)
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	userID := MustNew[UserID]()
+	accountID := MustNew[AccountID]()
+	merchantID := Must(FromName[MerchantID]("merchant"))
+	shardedID := Must(FromUUID[RegionID](Must(newShardedUUID(time.UnixMilli(1700000000000), 3, 8))))
+	customerSuffix := strings.TrimPrefix(MustNew[CustomerID]().String(), "customer_")
+
+	for _, tt := range []struct {
+		name                  string
+		id                    string
+		expectedPrefix        string
+		expectedKind          Kind
+		expectedUUID          uuid.UUID
+		expectedTime          time.Time
+		expectedInconsistency string
+	}{
+		{
+			name:           "random",
+			id:             userID.String(),
+			expectedPrefix: "user",
+			expectedKind:   KindRandom,
+			expectedUUID:   userID.UUID(),
+		},
+		{
+			name:           "sortable",
+			id:             accountID.String(),
+			expectedPrefix: "system_account",
+			expectedKind:   KindSortable,
+			expectedUUID:   accountID.UUID(),
+			expectedTime:   Must(Must(uuid.TimestampFromV7(accountID.UUID())).Time()),
+		},
+		{
+			name:           "deterministic",
+			id:             merchantID.String(),
+			expectedPrefix: "merchant",
+			expectedKind:   KindDeterministic,
+			expectedUUID:   merchantID.UUID(),
+		},
+		{
+			name:           "sharded",
+			id:             shardedID.String(),
+			expectedPrefix: "payment",
+			expectedKind:   KindSharded,
+			expectedUUID:   shardedID.UUID(),
+			expectedTime:   time.UnixMilli(1700000000000),
+		},
+		{
+			name:           "environment",
+			id:             "customer_test_" + customerSuffix,
+			expectedPrefix: "customer_test",
+			expectedKind:   KindSortable,
+		},
+		{
+			name:           "nil",
+			id:             Nil[UserID]().String(),
+			expectedPrefix: "user",
+			expectedKind:   KindUnknown,
+			expectedUUID:   uuid.Nil,
+		},
+		{
+			name:                  "lowercase random",
+			id:                    strings.ToLower(userID.String()),
+			expectedPrefix:        "user",
+			expectedKind:          KindUnknown,
+			expectedUUID:          userID.UUID(),
+			expectedInconsistency: "lowercase suffix holds a UUIDv4, which random IDs encode in uppercase",
+		},
+		{
+			name:                  "uppercase sortable",
+			id:                    "system_account_" + strings.ToUpper(strings.TrimPrefix(accountID.String(), "system_account_")),
+			expectedPrefix:        "system_account",
+			expectedKind:          KindUnknown,
+			expectedUUID:          accountID.UUID(),
+			expectedInconsistency: "uppercase suffix holds a UUIDv7, which sortable IDs encode in lowercase",
+		},
+		{
+			name:                  "unknown version",
+			id:                    "legacy_" + base32.EncodeLower([16]byte(Must(uuid.NewV1()))),
+			expectedPrefix:        "legacy",
+			expectedKind:          KindUnknown,
+			expectedInconsistency: "UUID version 1 is not used by any ID type",
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := Inspect(tc.id)
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if info.Prefix != tc.expectedPrefix {
+				t.Errorf("expected prefix %q, got %q", tc.expectedPrefix, info.Prefix)
+			}
+			if info.Kind != tc.expectedKind {
+				t.Errorf("expected kind %s, got %s", tc.expectedKind, info.Kind)
+			}
+			if tc.expectedUUID != uuid.Nil && info.UUID != tc.expectedUUID {
+				t.Errorf("expected UUID %s, got %s", tc.expectedUUID, info.UUID)
+			}
+			if info.Version != info.UUID.Version() || info.Variant != info.UUID.Variant() {
+				t.Errorf("expected version %d and variant %d, got %d and %d", info.UUID.Version(), info.UUID.Variant(), info.Version, info.Variant)
+			}
+			if !tc.expectedTime.IsZero() && !info.Time.Equal(tc.expectedTime) {
+				t.Errorf("expected time %s, got %s", tc.expectedTime, info.Time)
+			}
+			if tc.expectedInconsistency == "" && len(info.Inconsistencies) > 0 {
+				t.Errorf("unexpected inconsistencies %q", info.Inconsistencies)
+			}
+			if tc.expectedInconsistency != "" && (len(info.Inconsistencies) != 1 || info.Inconsistencies[0] != tc.expectedInconsistency) {
+				t.Errorf("expected inconsistency %q, got %q", tc.expectedInconsistency, info.Inconsistencies)
+			}
+		})
+	}
+}
+
+func TestInspect_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{
+		"",
+		"user_",
+		"user_01hf98sp99fs2b4qf2jm11hse",
+		"user_81hf98sp99fs2b4qf2jm11hse4",
+		"user_01hf98sp99fs2b4qf2jm11hseu",
+		"User_01hf98sp99fs2b4qf2jm11hse4",
+	} {
+		if _, err := Inspect(s); !errors.Is(err, ErrParse) {
+			t.Errorf("%q: expected ErrParse, got %v", s, err)
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		actual, expected Kind
+	}{
+		{actual: KindOf[UserID](), expected: KindRandom},
+		{actual: KindOf[CustomerID](), expected: KindSortable},
+		{actual: KindOf[MerchantID](), expected: KindDeterministic},
+		{actual: KindOf[RegionID](), expected: KindSharded},
+	} {
+		if tc.actual != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, tc.actual)
+		}
+	}
+}
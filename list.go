@@ -0,0 +1,147 @@
+package typeid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTooManyIDs is returned by [ParseList] and [ParseStrings] if the input contains more IDs than allowed by [WithMaxCount].
+var ErrTooManyIDs = errors.New("too many typeids")
+
+// ListOption configures [ParseList] and [ParseStrings].
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	maxCount int
+	dedupe   bool
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	var o listOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMaxCount limits the number of IDs in the input to n, including duplicates. Longer input fails with [ErrTooManyIDs]
+// before any ID is parsed.
+func WithMaxCount(n int) ListOption {
+	return func(o *listOptions) {
+		o.maxCount = n
+	}
+}
+
+// WithDedupe removes duplicate IDs from the result, keeping the first occurrence of each ID.
+func WithDedupe() ListOption {
+	return func(o *listOptions) {
+		o.dedupe = true
+	}
+}
+
+// IndexError is the error of a single element of the input of [ParseList] or [ParseStrings].
+type IndexError struct {
+	// Index is the index of the element in the input.
+	Index int
+	// Err is the error of parsing the element with [FromString].
+	Err error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// ListError is returned by [ParseList] and [ParseStrings] if elements of the input are no valid IDs.
+// It lists the errors of all invalid elements.
+type ListError struct {
+	Errors []*IndexError
+}
+
+func (e *ListError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "parse typeid list: " + strings.Join(msgs, "; ")
+}
+
+func (e *ListError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ParseList parses a list of IDs of the specified type separated by sep, e.g. the value of a query parameter.
+// An empty string results in an empty list. If any element is not a valid ID, it returns a [*ListError].
+//
+// Example:
+//
+//	ids, err := typeid.ParseList[OrderID](r.URL.Query().Get("ids"), ",", typeid.WithMaxCount(100), typeid.WithDedupe())
+func ParseList[T instance[P], P Prefix](s, sep string, opts ...ListOption) ([]T, error) {
+	if sep == "" {
+		return nil, errors.New("parse typeid list: empty separator")
+	}
+	if s == "" {
+		return []T{}, nil
+	}
+
+	// Check the count before splitting, to not allocate for overlong input.
+	if err := newListOptions(opts).checkCount(strings.Count(s, sep) + 1); err != nil {
+		return nil, err
+	}
+	return ParseStrings[T](strings.Split(s, sep), opts...)
+}
+
+// ParseStrings parses a list of IDs of the specified type, e.g. the values of a repeated query parameter.
+// If any element is not a valid ID, it returns a [*ListError].
+//
+// Example:
+//
+//	ids, err := typeid.ParseStrings[OrderID](r.URL.Query()["id"], typeid.WithMaxCount(100))
+func ParseStrings[T instance[P], P Prefix](ss []string, opts ...ListOption) ([]T, error) {
+	o := newListOptions(opts)
+	if err := o.checkCount(len(ss)); err != nil {
+		return nil, err
+	}
+
+	ids := make([]T, 0, len(ss))
+	var seen map[T]struct{}
+	if o.dedupe {
+		seen = make(map[T]struct{}, len(ss))
+	}
+	var errs []*IndexError
+	for i, s := range ss {
+		id, err := FromString[T](s)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+
+		if o.dedupe {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+		}
+		ids = append(ids, id)
+	}
+
+	if len(errs) > 0 {
+		return nil, &ListError{Errors: errs}
+	}
+	return ids, nil
+}
+
+func (o listOptions) checkCount(n int) error {
+	if o.maxCount > 0 && n > o.maxCount {
+		return fmt.Errorf("parse typeid list: %w: got %d, expected at most %d", ErrTooManyIDs, n, o.maxCount)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package typeid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	t.Parallel()
+
+	a, b := MustNew[AccountID](), MustNew[AccountID]()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		ids, err := ParseList[AccountID](a.String()+","+b.String()+","+a.String(), ",")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if len(ids) != 3 || ids[0] != a || ids[1] != b || ids[2] != a {
+			t.Errorf("expected [%s %s %s], got %v", a, b, a, ids)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		ids, err := ParseList[AccountID]("", ",")
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if ids == nil || len(ids) != 0 {
+			t.Errorf("expected an empty list, got %v", ids)
+		}
+	})
+
+	t.Run("dedupe", func(t *testing.T) {
+		t.Parallel()
+
+		ids, err := ParseList[AccountID](strings.Join([]string{a.String(), b.String(), a.String(), b.String()}, " "), " ", WithDedupe())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if len(ids) != 2 || ids[0] != a || ids[1] != b {
+			t.Errorf("expected [%s %s], got %v", a, b, ids)
+		}
+	})
+
+	t.Run("max count", func(t *testing.T) {
+		t.Parallel()
+
+		s := a.String() + "," + b.String() + "," + a.String()
+		if _, err := ParseList[AccountID](s, ",", WithMaxCount(2), WithDedupe()); !errors.Is(err, ErrTooManyIDs) {
+			t.Errorf("expected ErrTooManyIDs, got %v", err)
+		}
+		if _, err := ParseList[AccountID](s, ",", WithMaxCount(3)); err != nil {
+			t.Errorf("unexpected error:\n%+v", err)
+		}
+	})
+
+	t.Run("invalid elements", func(t *testing.T) {
+		t.Parallel()
+
+		userID := MustNew[UserID]()
+		_, err := ParseList[AccountID](a.String()+","+userID.String()+",,"+b.String()+",system_account_01", ",")
+
+		var listErr *ListError
+		if !errors.As(err, &listErr) {
+			t.Fatalf("expected a ListError, got %v", err)
+		}
+		if len(listErr.Errors) != 3 {
+			t.Fatalf("expected 3 errors, got %v", listErr)
+		}
+		for i, expected := range []struct {
+			index int
+			err   error
+		}{
+			{index: 1, err: ErrInvalidPrefix},
+			{index: 2, err: ErrInvalidPrefix},
+			{index: 4, err: ErrInvalidSuffix},
+		} {
+			if listErr.Errors[i].Index != expected.index || !errors.Is(listErr.Errors[i], expected.err) {
+				t.Errorf("expected %v at index %d, got %v", expected.err, expected.index, listErr.Errors[i])
+			}
+		}
+		if !errors.Is(err, ErrParse) || !errors.Is(err, ErrInvalidSuffix) {
+			t.Errorf("expected the error to wrap the errors of all elements, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "index 1: ") || !strings.Contains(err.Error(), "index 4: ") {
+			t.Errorf("expected the error to list the failing indexes, got %v", err)
+		}
+	})
+
+	t.Run("empty separator", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := ParseList[AccountID](a.String(), ""); err == nil {
+			t.Error("expected an error for an empty separator")
+		}
+	})
+}
+
+func TestParseStrings(t *testing.T) {
+	t.Parallel()
+
+	a, b := MustNew[UserID](), MustNew[UserID]()
+
+	ids, err := ParseStrings[UserID]([]string{a.String(), b.String(), b.String()}, WithDedupe(), WithMaxCount(3))
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if len(ids) != 2 || ids[0] != a || ids[1] != b {
+		t.Errorf("expected [%s %s], got %v", a, b, ids)
+	}
+
+	if _, err := ParseStrings[UserID]([]string{a.String(), b.String()}, WithMaxCount(1)); !errors.Is(err, ErrTooManyIDs) {
+		t.Errorf("expected ErrTooManyIDs, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package typeid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/sumup/typeid/base32"
+)
+
+const (
+	obfuscatedKeyIDLen  = 2 // base32 of a single byte
+	obfuscatedSuffixLen = obfuscatedKeyIDLen + suffixStrLen
+)
+
+// UnknownKeyError is returned when revealing an ID that was obfuscated with a key unknown to the [Obfuscator].
+type UnknownKeyError struct {
+	KeyID byte
+}
+
+func (e *UnknownKeyError) Error() string {
+	return fmt.Sprintf("unknown obfuscation key %d", e.KeyID)
+}
+
+// ObfuscationKey is a key used by an [Obfuscator].
+type ObfuscationKey struct {
+	// ID identifies the key. It is part of the obfuscated IDs to support key rotation.
+	ID byte
+	// Key is the AES key, either 16, 24 or 32 bytes long.
+	Key []byte
+}
+
+// Obfuscator maps [Sortable] IDs to opaque public IDs and back. Sortable IDs reveal their creation time,
+// which might leak business information like the volume of orders when exposed to clients.
+//
+// Obfuscated IDs keep the prefix of the ID type, while the suffix is the ID of the key followed by the
+// AES encryption of the UUID bytes, e.g. order_01361fy4mntv3dhvwm7hvbek51bb.
+// Note that obfuscation is not authentication, it does not detect forged IDs.
+type Obfuscator struct {
+	primary byte
+	blocks  map[byte]cipher.Block
+}
+
+// NewObfuscator returns a new [Obfuscator] for the given keys. The first key is used to obfuscate IDs,
+// while all keys are used to reveal them. To rotate keys, add the new key in first position and keep the
+// old ones, as long as obfuscated IDs encrypted with them are in use.
+func NewObfuscator(keys ...ObfuscationKey) (*Obfuscator, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("new obfuscator: at least one key is required")
+	}
+
+	o := &Obfuscator{
+		primary: keys[0].ID,
+		blocks:  make(map[byte]cipher.Block, len(keys)),
+	}
+	for _, k := range keys {
+		if _, ok := o.blocks[k.ID]; ok {
+			return nil, fmt.Errorf("new obfuscator: duplicate key id %d", k.ID)
+		}
+		block, err := aes.NewCipher(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("new obfuscator: key %d: %w", k.ID, err)
+		}
+		o.blocks[k.ID] = block
+	}
+	return o, nil
+}
+
+// Obfuscate returns the obfuscated string representation of the given ID.
+// Nil IDs are obfuscated like any other ID and revealed as the nil ID by [Deobfuscate], [Obfuscated] applies the
+// [NilMarshaling] of the prefix instead.
+//
+// Example:
+//
+//	publicID := typeid.Obfuscate(obfuscator, orderID)
+func Obfuscate[P Prefix](o *Obfuscator, id Sortable[P]) string {
+	var encrypted [16]byte
+	u := id.UUID()
+	o.blocks[o.primary].Encrypt(encrypted[:], u[:])
+
+	prefix := getPrefix[P]()
+	var sb strings.Builder
+	sb.Grow(len(prefix) + 1 + obfuscatedSuffixLen)
+	if prefix != "" {
+		sb.WriteString(prefix)
+		sb.WriteByte('_')
+	}
+	sb.WriteString(base32.EncodeLowerBytes([]byte{o.primary}))
+	sb.WriteString(base32.EncodeLower(encrypted))
+	return sb.String()
+}
+
+// sortableInstance is a helper constraint for functions that only support [Sortable] IDs.
+type sortableInstance[P Prefix] interface {
+	Sortable[P]
+	instance[P]
+}
+
+// Deobfuscate reveals the ID of the specified type from a string obfuscated with [Obfuscate].
+// If the ID was obfuscated with an unknown key, it returns an [UnknownKeyError]. Obfuscated IDs only store the UUID of
+// the ID, which is revealed in the current environment, see [FromUUID].
+//
+// Example:
+//
+//	orderID, err := typeid.Deobfuscate[OrderID](obfuscator, publicID)
+func Deobfuscate[T sortableInstance[P], P Prefix](o *Obfuscator, s string) (T, error) {
+	prefix := getPrefix[P]()
+	if err := validatePrefix(prefix); err != nil {
+		return Nil[T](), err
+	}
+	if prefix != "" && !strings.HasPrefix(s, prefix+"_") {
+		return Nil[T](), fmt.Errorf("%w: %w for %T, expected %q", ErrParse, ErrInvalidPrefix, T{}, prefix)
+	}
+
+	suffix := strings.TrimPrefix(s, prefix+"_")
+	if len(suffix) != obfuscatedSuffixLen {
+		return Nil[T](), fmt.Errorf("%w: %w %q: suffix length is %d, expected %d", ErrParse, ErrInvalidSuffix, suffix, len(suffix), obfuscatedSuffixLen)
+	}
+
+	keyID, err := base32.DecodeLowerBytes(suffix[:obfuscatedKeyIDLen])
+	if err != nil {
+		return Nil[T](), fmt.Errorf("%w: invalid key id: %s", ErrParse, err.Error())
+	}
+	block, ok := o.blocks[keyID[0]]
+	if !ok {
+		return Nil[T](), &UnknownKeyError{KeyID: keyID[0]}
+	}
+
+	encrypted, err := decodeSuffix([]byte(suffix[obfuscatedKeyIDLen:]), sortableIDProc)
+	if err != nil {
+		return Nil[T](), fmt.Errorf("%w: %w %q: %s", ErrParse, ErrInvalidSuffix, suffix, err.Error())
+	}
+
+	var u uuid.UUID
+	block.Decrypt(u[:], encrypted[:])
+	if !u.IsNil() && (u.Version() != uuid.V7 || u.Variant() != uuid.VariantRFC9562) {
+		// Any input decrypts to some UUID, but only a fraction of them are valid UUIDv7.
+		return Nil[T](), fmt.Errorf("%w: invalid obfuscated id %q", ErrParse, s)
+	}
+	tid, err := newTypedID[P](u)
+	if err != nil {
+		return Nil[T](), err
+	}
+	return T{tid}, nil
+}
+
+// ObfuscationKeyring provides the [Obfuscator] of an [Obfuscated] ID type.
+//
+// Example:
+//
+//	type OrderKeyring struct{}
+//
+//	func (OrderKeyring) Obfuscator() *typeid.Obfuscator {
+//	    return orderObfuscator
+//	}
+type ObfuscationKeyring interface {
+	Obfuscator() *Obfuscator
+}
+
+// Obfuscated wraps a [Sortable] ID to marshal it to and from its obfuscated string representation,
+// see [Obfuscator]. Use it in the types exposed to clients, e.g. API responses.
+//
+// Example:
+//
+//	type PublicOrderID = typeid.Obfuscated[OrderPrefix, OrderKeyring]
+//
+//	type OrderResponse struct {
+//	    ID PublicOrderID `json:"id"`
+//	}
+type Obfuscated[P Prefix, K ObfuscationKeyring] struct {
+	ID Sortable[P]
+}
+
+func getObfuscator[K ObfuscationKeyring]() (*Obfuscator, error) {
+	var keyring K
+	o := keyring.Obfuscator()
+	if o == nil {
+		return nil, fmt.Errorf("no obfuscator provided by %T", keyring)
+	}
+	return o, nil
+}
+
+// String returns the obfuscated string representation of the ID.
+// If the keyring does not provide an [Obfuscator], it returns an empty string.
+func (o Obfuscated[P, K]) String() string {
+	text, err := o.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// It obfuscates the ID using [Obfuscate]. Nil IDs are marshaled according to the [NilMarshaling] of the prefix,
+// like [Sortable.MarshalText] does.
+func (o Obfuscated[P, K]) MarshalText() ([]byte, error) {
+	if o.ID.IsNil() {
+		switch getNilMarshaling[P]() {
+		case NilMarshalError:
+			return nil, fmt.Errorf("marshal %T: %w", o, ErrNilID)
+		case NilMarshalEmpty:
+			return []byte{}, nil
+		case NilMarshalDefault:
+		}
+	}
+
+	obfuscator, err := getObfuscator[K]()
+	if err != nil {
+		return nil, fmt.Errorf("marshal typeid.Obfuscated: %w", err)
+	}
+	return []byte(Obfuscate(obfuscator, o.ID)), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// It reveals the ID using [Deobfuscate]. For prefixes with [NilMarshalEmpty], an empty text is the nil ID.
+func (o *Obfuscated[P, K]) UnmarshalText(text []byte) error {
+	if len(text) == 0 && getNilMarshaling[P]() == NilMarshalEmpty {
+		o.ID = Nil[Sortable[P]]()
+		return nil
+	}
+
+	obfuscator, err := getObfuscator[K]()
+	if err != nil {
+		return fmt.Errorf("unmarshal text to typeid.Obfuscated: %w", err)
+	}
+
+	o.ID, err = Deobfuscate[Sortable[P]](obfuscator, string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal text to typeid.Obfuscated: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package typeid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type orderPrefix struct{}
+
+func (orderPrefix) Prefix() string {
+	return "order"
+}
+
+type OrderID = Sortable[orderPrefix]
+
+var (
+	oldObfuscationKey = ObfuscationKey{ID: 1, Key: bytes.Repeat([]byte{1}, 16)}
+	newObfuscationKey = ObfuscationKey{ID: 2, Key: bytes.Repeat([]byte{2}, 32)}
+	testObfuscator    = Must(NewObfuscator(newObfuscationKey, oldObfuscationKey))
+)
+
+type testKeyring struct{}
+
+func (testKeyring) Obfuscator() *Obfuscator {
+	return testObfuscator
+}
+
+func TestObfuscate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[OrderID]()
+		public := Obfuscate(testObfuscator, id)
+		if !strings.HasPrefix(public, "order_") || len("order_")+obfuscatedSuffixLen != len(public) {
+			t.Errorf("unexpected obfuscated id format: %s", public)
+		}
+		if strings.Contains(public, strings.TrimPrefix(id.String(), "order_")[:10]) {
+			t.Errorf("obfuscated id must not contain the timestamp of the id: %s", public)
+		}
+
+		revealed, err := Deobfuscate[OrderID](testObfuscator, public)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != revealed {
+			t.Errorf("expected %s, got %s", id, revealed)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		revealed, err := Deobfuscate[OrderID](testObfuscator, Obfuscate(testObfuscator, Nil[OrderID]()))
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !revealed.IsNil() {
+			t.Errorf("expected the nil id, got %s", revealed)
+		}
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[OrderID]()
+		public := Obfuscate(Must(NewObfuscator(oldObfuscationKey)), id)
+
+		revealed, err := Deobfuscate[OrderID](testObfuscator, public)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != revealed {
+			t.Errorf("expected %s, got %s", id, revealed)
+		}
+
+		_, err = Deobfuscate[OrderID](Must(NewObfuscator(oldObfuscationKey)), Obfuscate(testObfuscator, id))
+		var unknownKeyErr *UnknownKeyError
+		if !errors.As(err, &unknownKeyErr) {
+			t.Fatalf("expected UnknownKeyError, got %v", err)
+		}
+		if newObfuscationKey.ID != unknownKeyErr.KeyID {
+			t.Errorf("expected key id %d, got %d", newObfuscationKey.ID, unknownKeyErr.KeyID)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[OrderID]()
+		for _, invalid := range []string{
+			id.String(),
+			"user_" + strings.TrimPrefix(Obfuscate(testObfuscator, id), "order_"),
+			"order_02" + strings.Repeat("z", suffixStrLen),
+			"order_02" + strings.Repeat("0", suffixStrLen),
+		} {
+			if _, err := Deobfuscate[OrderID](testObfuscator, invalid); !errors.Is(err, ErrParse) {
+				t.Errorf("revealing %s: expected ErrParse, got %v", invalid, err)
+			}
+		}
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewObfuscator(); err == nil {
+			t.Errorf("expected error without keys")
+		}
+		if _, err := NewObfuscator(ObfuscationKey{ID: 1, Key: []byte("short")}); err == nil {
+			t.Errorf("expected error for invalid key size")
+		}
+		if _, err := NewObfuscator(oldObfuscationKey, oldObfuscationKey); err == nil {
+			t.Errorf("expected error for duplicate key ids")
+		}
+	})
+}
+
+func TestObfuscated_JSON(t *testing.T) {
+	t.Parallel()
+
+	type response struct {
+		ID Obfuscated[orderPrefix, testKeyring] `json:"id"`
+	}
+
+	id := MustNew[OrderID]()
+	encoded, err := json.Marshal(response{ID: Obfuscated[orderPrefix, testKeyring]{ID: id}})
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if `{"id":"`+Obfuscate(testObfuscator, id)+`"}` != string(encoded) {
+		t.Errorf("expected obfuscated id, got %s", encoded)
+	}
+
+	var decoded response
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if id != decoded.ID.ID {
+		t.Errorf("expected %s, got %s", id, decoded.ID.ID)
+	}
+}
+
+func TestObfuscated_Nil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshal error", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := (Obfuscated[strictNilPrefix, testKeyring]{}).MarshalText(); !errors.Is(err, ErrNilID) {
+			t.Errorf("expected ErrNilID, got %v", err)
+		}
+	})
+
+	t.Run("nil marshal empty", func(t *testing.T) {
+		t.Parallel()
+
+		text, err := (Obfuscated[emptyNilPrefix, testKeyring]{}).MarshalText()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if len(text) != 0 {
+			t.Errorf("expected an empty text, got %s", text)
+		}
+
+		decoded := Obfuscated[emptyNilPrefix, testKeyring]{ID: MustNew[Sortable[emptyNilPrefix]]()}
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !decoded.ID.IsNil() {
+			t.Errorf("expected the nil ID, got %s", decoded.ID)
+		}
+	})
+
+	t.Run("nil marshal default", func(t *testing.T) {
+		t.Parallel()
+
+		text, err := (Obfuscated[orderPrefix, testKeyring]{}).MarshalText()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		decoded := Obfuscated[orderPrefix, testKeyring]{ID: MustNew[OrderID]()}
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if decoded != (Obfuscated[orderPrefix, testKeyring]{}) {
+			t.Errorf("expected the zero value, got %s", decoded)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+// Package pagination implements keyset pagination based on [typeid.Sortable] IDs.
+//
+// A [Cursor] points to the last row of a page. It is encoded as an opaque string, which can be returned to clients
+// and passed back to fetch the next page. A [Keyset] describes the columns the rows are ordered by and renders the
+// matching SQL fragments for PostgreSQL.
+//
+// Example:
+//
+//	keyset := pagination.Keyset{IDColumn: "id", Storage: pagination.StorageUUID}
+//	cursor, err := pagination.Parse[OrderPrefix](r.URL.Query().Get("cursor"))
+//	...
+//	where, args, err := cursor.Where(keyset, 1)
+//	...
+//	rows, err := db.Query(ctx, "SELECT ... FROM orders WHERE "+where+" ORDER BY "+keyset.OrderBy(cursor.Direction)+" LIMIT 50", args...)
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/base32"
+)
+
+// ErrInvalidCursor is returned when parsing a malformed cursor.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorVersion is the version of the encoding of cursors, which is the first byte of an encoded cursor.
+const cursorVersion = 1
+
+// cursorHeaderLen is the length of an encoded cursor without its sort value: version, direction and UUID.
+const cursorHeaderLen = 2 + 16
+
+// Direction is the direction of pagination.
+type Direction byte
+
+const (
+	// Forward selects the rows following the cursor in ascending order.
+	Forward Direction = iota
+	// Backward selects the rows preceding the cursor in descending order.
+	Backward
+)
+
+// Storage is the column type IDs are stored in.
+type Storage int
+
+const (
+	// StorageText stores IDs in their string representation, see [typeid.Sortable.TextValue].
+	StorageText Storage = iota
+	// StorageUUID stores IDs as UUIDs, see [typeid.Sortable.UUIDValue].
+	StorageUUID
+)
+
+// Cursor points to the last row of a page.
+type Cursor[P typeid.Prefix] struct {
+	// ID is the ID of the last row.
+	ID typeid.Sortable[P]
+	// Direction is the direction of the next page.
+	Direction Direction
+	// SortValue is the value of the secondary sort column of the last row in its text representation,
+	// if the rows are ordered by a [Keyset] with a SortColumn.
+	SortValue string
+}
+
+// Parse parses a cursor encoded with [Cursor.String]. Cursors only store the UUID of the ID, which is restored in the
+// current environment, see [typeid.FromUUID].
+func Parse[P typeid.Prefix](s string) (Cursor[P], error) {
+	b, err := base32.DecodeLowerBytes(s)
+	if err != nil {
+		return Cursor[P]{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if len(b) < cursorHeaderLen {
+		return Cursor[P]{}, fmt.Errorf("%w: got %d bytes, expected at least %d", ErrInvalidCursor, len(b), cursorHeaderLen)
+	}
+	if b[0] != cursorVersion {
+		return Cursor[P]{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidCursor, b[0])
+	}
+
+	dir := Direction(b[1])
+	switch dir {
+	case Forward, Backward:
+	default:
+		return Cursor[P]{}, fmt.Errorf("%w: unknown direction %d", ErrInvalidCursor, dir)
+	}
+
+	id, err := typeid.FromUUIDBytes[typeid.Sortable[P]](b[2:cursorHeaderLen])
+	if err != nil {
+		return Cursor[P]{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return Cursor[P]{ID: id, Direction: dir, SortValue: string(b[cursorHeaderLen:])}, nil
+}
+
+// String returns the opaque string representation of the cursor.
+func (c Cursor[P]) String() string {
+	b := make([]byte, 0, cursorHeaderLen+len(c.SortValue))
+	b = append(b, cursorVersion, byte(c.Direction))
+	b = append(b, c.ID.UUID().Bytes()...)
+	b = append(b, c.SortValue...)
+	return base32.EncodeLowerBytes(b)
+}
+
+func (c Cursor[P]) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Cursor[P]) UnmarshalText(text []byte) error {
+	var err error
+	*c, err = Parse[P](string(text))
+	return err
+}
+
+// Where returns the SQL condition selecting the rows after the cursor in its direction, ordered by the keyset,
+// and the corresponding arguments. Placeholders are numbered starting at n, e.g. $1 for n = 1.
+func (c Cursor[P]) Where(k Keyset, n int) (string, []any, error) {
+	op := ">"
+	if c.Direction == Backward {
+		op = "<"
+	}
+
+	var id any
+	var err error
+	switch k.Storage {
+	case StorageText:
+		id, err = c.ID.TextValue()
+	case StorageUUID:
+		id, err = c.ID.UUIDValue()
+	default:
+		return "", nil, fmt.Errorf("unknown storage %d", k.Storage)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	if k.SortColumn == "" {
+		return k.idColumn() + " " + op + " " + placeholder(n, ""), []any{id}, nil
+	}
+	where := "(" + k.SortColumn + ", " + k.idColumn() + ") " + op + " (" + placeholder(n, k.SortType) + ", " + placeholder(n+1, "") + ")"
+	return where, []any{c.SortValue, id}, nil
+}
+
+// Keyset describes the columns rows are ordered by. Rows are ordered by the optional sort column first and then by ID.
+type Keyset struct {
+	// IDColumn is the column of the ID, e.g. "id".
+	IDColumn string
+	// SortColumn is the optional secondary sort column, e.g. "created_at".
+	SortColumn string
+	// SortType is the PostgreSQL type the SortValue of cursors is cast to, e.g. "timestamptz".
+	// If empty, the sort value is passed without cast.
+	SortType string
+	// Storage is the column type the IDs are stored in.
+	Storage Storage
+}
+
+// OrderBy returns the SQL ORDER BY expression matching [Cursor.Where] for the given direction.
+// Pages of the [Backward] direction are ordered descending and need to be reversed by the caller.
+func (k Keyset) OrderBy(dir Direction) string {
+	order := " ASC"
+	if dir == Backward {
+		order = " DESC"
+	}
+
+	if k.SortColumn == "" {
+		return k.idColumn() + order
+	}
+	return k.SortColumn + order + ", " + k.idColumn() + order
+}
+
+// idColumn returns the ID column. Text columns are compared bytewise, as the order of other collations may differ
+// from the order of the IDs.
+func (k Keyset) idColumn() string {
+	if k.Storage == StorageText {
+		return k.IDColumn + ` COLLATE "C"`
+	}
+	return k.IDColumn
+}
+
+func placeholder(n int, typ string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	sb.WriteString(strconv.Itoa(n))
+	if typ != "" {
+		sb.WriteString("::")
+		sb.WriteString(typ)
+	}
+	return sb.String()
+}
@@ -0,0 +1,160 @@
+package pagination_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/base32"
+	"github.com/sumup/typeid/pagination"
+)
+
+type orderPrefix struct{}
+
+func (orderPrefix) Prefix() string {
+	return "order"
+}
+
+type OrderID = typeid.Sortable[orderPrefix]
+
+func TestCursor_String(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		cursor pagination.Cursor[orderPrefix]
+	}{
+		{
+			name:   "forward",
+			cursor: pagination.Cursor[orderPrefix]{ID: typeid.MustNew[OrderID]()},
+		},
+		{
+			name: "backward with sort value",
+			cursor: pagination.Cursor[orderPrefix]{
+				ID:        typeid.MustNew[OrderID](),
+				Direction: pagination.Backward,
+				SortValue: "2024-02-07T08:28:55.398Z",
+			},
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := pagination.Parse[orderPrefix](tc.cursor.String())
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if parsed != tc.cursor {
+				t.Errorf("expected %+v, got %+v", tc.cursor, parsed)
+			}
+
+			text, err := tc.cursor.MarshalText()
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			var unmarshaled pagination.Cursor[orderPrefix]
+			if err := unmarshaled.UnmarshalText(text); err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if unmarshaled != tc.cursor {
+				t.Errorf("expected %+v, got %+v", tc.cursor, unmarshaled)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[OrderID]()
+	encode := func(b ...byte) string {
+		return base32.EncodeLowerBytes(append(b, id.UUID().Bytes()...))
+	}
+
+	for name, s := range map[string]string{
+		"not base32":        "cursor!",
+		"too short":         base32.EncodeLowerBytes([]byte{1, 0, 1, 2}),
+		"unknown version":   encode(2, 0),
+		"unknown direction": encode(1, 2),
+	} {
+		if _, err := pagination.Parse[orderPrefix](s); !errors.Is(err, pagination.ErrInvalidCursor) {
+			t.Errorf("%s: expected ErrInvalidCursor, got %v", name, err)
+		}
+	}
+}
+
+func TestCursor_Where(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[OrderID]()
+	textValue, _ := id.TextValue()
+	uuidValue, _ := id.UUIDValue()
+
+	for _, tt := range []struct {
+		name            string
+		cursor          pagination.Cursor[orderPrefix]
+		keyset          pagination.Keyset
+		n               int
+		expectedWhere   string
+		expectedOrderBy string
+		expectedArgs    []any
+	}{
+		{
+			name:            "text forward",
+			cursor:          pagination.Cursor[orderPrefix]{ID: id},
+			keyset:          pagination.Keyset{IDColumn: "id"},
+			n:               1,
+			expectedWhere:   `id COLLATE "C" > $1`,
+			expectedOrderBy: `id COLLATE "C" ASC`,
+			expectedArgs:    []any{textValue},
+		},
+		{
+			name:            "uuid backward",
+			cursor:          pagination.Cursor[orderPrefix]{ID: id, Direction: pagination.Backward},
+			keyset:          pagination.Keyset{IDColumn: "o.id", Storage: pagination.StorageUUID},
+			n:               3,
+			expectedWhere:   `o.id < $3`,
+			expectedOrderBy: `o.id DESC`,
+			expectedArgs:    []any{uuidValue},
+		},
+		{
+			name:            "uuid with sort column",
+			cursor:          pagination.Cursor[orderPrefix]{ID: id, SortValue: "2024-02-07T08:28:55.398Z"},
+			keyset:          pagination.Keyset{IDColumn: "id", SortColumn: "created_at", SortType: "timestamptz", Storage: pagination.StorageUUID},
+			n:               1,
+			expectedWhere:   `(created_at, id) > ($1::timestamptz, $2)`,
+			expectedOrderBy: `created_at ASC, id ASC`,
+			expectedArgs:    []any{"2024-02-07T08:28:55.398Z", uuidValue},
+		},
+		{
+			name:            "text backward with sort column",
+			cursor:          pagination.Cursor[orderPrefix]{ID: id, Direction: pagination.Backward, SortValue: "42"},
+			keyset:          pagination.Keyset{IDColumn: "id", SortColumn: "amount"},
+			n:               2,
+			expectedWhere:   `(amount, id COLLATE "C") < ($2, $3)`,
+			expectedOrderBy: `amount DESC, id COLLATE "C" DESC`,
+			expectedArgs:    []any{"42", textValue},
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			where, args, err := tc.cursor.Where(tc.keyset, tc.n)
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if where != tc.expectedWhere {
+				t.Errorf("expected WHERE %s, got %s", tc.expectedWhere, where)
+			}
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Errorf("expected arguments %v, got %v", tc.expectedArgs, args)
+			}
+			if orderBy := tc.keyset.OrderBy(tc.cursor.Direction); orderBy != tc.expectedOrderBy {
+				t.Errorf("expected ORDER BY %s, got %s", tc.expectedOrderBy, orderBy)
+			}
+		})
+	}
+}
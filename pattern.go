@@ -0,0 +1,79 @@
+package typeid
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Regular expressions matching the suffixes of the different base32 encodings. The first character is limited to 0-7,
+// see [decodeSuffix].
+const (
+	upperSuffixPattern = "[0-7][0-9A-HJKMNP-TV-Z]{25}"
+	lowerSuffixPattern = "[0-7][0-9a-hjkmnp-tv-z]{25}"
+	mixedSuffixPattern = "[0-7][0-9A-HJKMNP-TV-Z]{9}[0-9a-hjkmnp-tv-z]{16}"
+)
+
+// Pattern returns an anchored regular expression in RE2 syntax, which matches the string representations of IDs of
+// the specified type accepted by [FromString]: the prefix or one of its aliases, the qualifier of a non-default
+// environment and a suffix in the encoding of the ID type. Use it to validate IDs outside of Go, e.g. in
+// OpenAPI schemas, database constraints or protobuf validation rules.
+//
+// The expression only checks the syntax. It matches IDs with a wrong UUID version and IDs of other environments than
+// the one set with [SetEnvironment], which [FromString] rejects.
+//
+// Example:
+//
+//	typeid.Pattern[UserID]() // ^user_[0-7][0-9a-hjkmnp-tv-z]{25}$
+func Pattern[T instance[P], P Prefix]() string {
+	return pattern(getPrefix[P](), getPrefixAliases[P](), getEnvironments[P](), T{}.processor().suffixPattern)
+}
+
+// Pattern returns the regular expression matching IDs of the kind with the given prefix, aliases and environments,
+// like [Pattern] for ID types. Use it where the ID types are not available, e.g. in code generators.
+// It returns an empty string for [KindUnknown].
+//
+// Example:
+//
+//	typeid.KindSortable.Pattern("user", nil, nil) // ^user_[0-7][0-9a-hjkmnp-tv-z]{25}$
+func (k Kind) Pattern(prefix string, aliases, environments []string) string {
+	if k == KindUnknown {
+		return ""
+	}
+
+	suffixPattern := lowerSuffixPattern
+	switch k.casing() {
+	case casingUpper:
+		suffixPattern = upperSuffixPattern
+	case casingMixed:
+		suffixPattern = mixedSuffixPattern
+	case casingLower:
+	}
+	return pattern(prefix, aliases, environments, suffixPattern)
+}
+
+func pattern(prefix string, aliases, envs []string, suffixPattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	prefixes := []string{regexp.QuoteMeta(prefix)}
+	for _, alias := range aliases {
+		if prefix != "" && alias != "" && validatePrefix(alias) == nil {
+			prefixes = append(prefixes, regexp.QuoteMeta(alias))
+		}
+	}
+	switch {
+	case len(prefixes) > 1:
+		sb.WriteString("(" + strings.Join(prefixes, "|") + ")_")
+	case prefix != "":
+		sb.WriteString(prefixes[0] + "_")
+	}
+
+	// The default environment is never qualified.
+	if len(envs) > 1 {
+		sb.WriteString("((" + strings.Join(envs[1:], "|") + ")_)?")
+	}
+
+	sb.WriteString(suffixPattern)
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,109 @@
+package typeid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPattern(t *testing.T) {
+	t.Parallel()
+
+	accountID := MustNew[AccountID]()
+	accountSuffix := strings.TrimPrefix(accountID.String(), "system_account_")
+	transactionID := MustNew[TransactionID]()
+	customerID := MustNew[CustomerID]()
+	customerSuffix := strings.TrimPrefix(customerID.String(), "customer_")
+
+	for _, tt := range []struct {
+		name     string
+		pattern  string
+		expected string
+		matching []string
+		invalid  []string
+	}{
+		{
+			name:     "random",
+			pattern:  Pattern[UserID](),
+			expected: "^user_[0-7][0-9A-HJKMNP-TV-Z]{25}$",
+			matching: []string{MustNew[UserID]().String()},
+			invalid:  []string{strings.ToLower(MustNew[UserID]().String()), "user_" + MustNew[UserID]().String()},
+		},
+		{
+			name:     "sortable",
+			pattern:  Pattern[AccountID](),
+			expected: "^system_account_[0-7][0-9a-hjkmnp-tv-z]{25}$",
+			matching: []string{accountID.String()},
+			invalid:  []string{"user_" + accountSuffix, accountID.String() + "0", "system_account_8" + accountSuffix[1:]},
+		},
+		{
+			name:     "deterministic",
+			pattern:  Pattern[MerchantID](),
+			matching: []string{Must(FromName[MerchantID]("merchant")).String()},
+			invalid:  []string{strings.ToLower(Must(FromName[MerchantID]("merchant")).String())},
+		},
+		{
+			name:     "sharded",
+			pattern:  Pattern[RegionID](),
+			matching: []string{Nil[RegionID]().String()},
+		},
+		{
+			name:     "aliases",
+			pattern:  Pattern[TransactionID](),
+			expected: "^(transaction|txn|tx)_[0-7][0-9a-hjkmnp-tv-z]{25}$",
+			matching: []string{transactionID.String(), "txn_" + transactionID.String()[len("transaction_"):]},
+			invalid:  []string{"t_" + transactionID.String()[len("transaction_"):]},
+		},
+		{
+			name:     "environments",
+			pattern:  Pattern[CustomerID](),
+			expected: "^customer_((test)_)?[0-7][0-9a-hjkmnp-tv-z]{25}$",
+			matching: []string{customerID.String(), "customer_test_" + customerSuffix},
+			invalid:  []string{"customer_live_" + customerSuffix},
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.expected != "" && tc.pattern != tc.expected {
+				t.Errorf("expected pattern %s, got %s", tc.expected, tc.pattern)
+			}
+			re := regexp.MustCompile(tc.pattern)
+			for _, s := range tc.matching {
+				if !re.MatchString(s) {
+					t.Errorf("expected %s to match %s", tc.pattern, s)
+				}
+			}
+			for _, s := range tc.invalid {
+				if re.MatchString(s) {
+					t.Errorf("expected %s not to match %s", tc.pattern, s)
+				}
+			}
+		})
+	}
+}
+
+func TestKind_Pattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		kind         Kind
+		prefix       string
+		aliases      []string
+		environments []string
+		expected     string
+	}{
+		{kind: KindRandom, prefix: "user", expected: Pattern[UserID]()},
+		{kind: KindSortable, prefix: "system_account", expected: Pattern[AccountID]()},
+		{kind: KindDeterministic, prefix: "merchant", expected: Pattern[MerchantID]()},
+		{kind: KindSharded, prefix: "payment", expected: Pattern[RegionID]()},
+		{kind: KindSortable, prefix: "transaction", aliases: transactionPrefixAliases, expected: Pattern[TransactionID]()},
+		{kind: KindSortable, prefix: "customer", environments: customerEnvironments, expected: Pattern[CustomerID]()},
+		{kind: KindUnknown, prefix: "user"},
+	} {
+		if pattern := tt.kind.Pattern(tt.prefix, tt.aliases, tt.environments); pattern != tt.expected {
+			t.Errorf("%s %s: expected pattern %q, got %q", tt.kind, tt.prefix, tt.expected, pattern)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package typeid
 
 import (
+	"context"
 	"database/sql/driver"
+	"io"
+	"log/slog"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -20,14 +23,16 @@ var randomIDProc = &processor{
 	b32EncodeTo: func(dst []byte, u uuid.UUID) {
 		base32.EncodeUpperTo(dst, [16]byte(u))
 	},
-	b32Decode: func(s string) (uuid.UUID, error) {
-		decoded, err := base32.DecodeUpper(s)
-		if err != nil {
+	b32Decode: func(b []byte) (uuid.UUID, error) {
+		var u uuid.UUID
+		if err := base32.DecodeUpperTo((*[16]byte)(&u), b); err != nil {
 			return uuid.Nil, err
 		}
-		return uuid.FromBytes(decoded)
+		return u, nil
 	},
-	generateUUID: uuid.NewV4,
+	generateUUID:  uuid.NewV4,
+	version:       uuid.V4,
+	suffixPattern: upperSuffixPattern,
 }
 
 func (Random[P]) processor() *processor {
@@ -39,13 +44,30 @@ func (Random[P]) Type() string {
 }
 
 func (r Random[P]) String() string {
-	return toString[P](r.uuid, r.processor())
+	return toString[P](r.typedID, r.processor())
 }
 
 func (r Random[P]) UUID() uuid.UUID {
 	return r.uuid
 }
 
+// IsNil reports whether the ID is the nil ID, see [Nil].
+func (r Random[P]) IsNil() bool {
+	return r.uuid.IsNil()
+}
+
+// IsZero reports whether the ID is the zero value, which is equal to the nil ID.
+// It allows omitting unset IDs with the `json:",omitzero"` struct tag.
+func (r Random[P]) IsZero() bool {
+	return r.IsNil()
+}
+
+// Compare returns an integer comparing two IDs, see [Compare].
+// It can be used as a method expression, e.g. with [slices.SortFunc].
+func (r Random[P]) Compare(other Random[P]) int {
+	return Compare(r, other)
+}
+
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
 // It parses a TypeID string using [FromString]
 func (r *Random[P]) UnmarshalText(text []byte) error {
@@ -74,6 +96,35 @@ func (r *Random[P]) ScanText(v pgtype.Text) error {
 	return scanText(r, v)
 }
 
+// ScanBytes implements the [pgtype.BytesScanner] interface.
+func (r *Random[P]) ScanBytes(v []byte) error {
+	return scanBytes(r, v)
+}
+
+// MarshalGQL implements the graphql.Marshaler interface of gqlgen, see https://gqlgen.com/reference/scalars/.
+// It writes the ID as string and panics with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+// gqlgen prefers [Random.MarshalGQLContext], which returns the error instead.
+func (r Random[P]) MarshalGQL(w io.Writer) {
+	mustMarshalGQL(r, w)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen. It accepts strings, which are passed
+// for both custom scalars and the built-in ID type.
+func (r *Random[P]) UnmarshalGQL(v any) error {
+	return unmarshalGQL(r, v)
+}
+
+// MarshalGQLContext implements the graphql.ContextMarshaler interface of gqlgen. It writes the ID as string and
+// fails with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+func (r Random[P]) MarshalGQLContext(_ context.Context, w io.Writer) error {
+	return marshalGQL(r, w)
+}
+
+// UnmarshalGQLContext implements the graphql.ContextUnmarshaler interface of gqlgen, see [Random.UnmarshalGQL].
+func (r *Random[P]) UnmarshalGQLContext(_ context.Context, v any) error {
+	return unmarshalGQL(r, v)
+}
+
 func (r Random[P]) UUIDValue() (pgtype.UUID, error) {
 	return uuidValue(r)
 }
@@ -81,3 +132,9 @@ func (r Random[P]) UUIDValue() (pgtype.UUID, error) {
 func (r *Random[P]) ScanUUID(v pgtype.UUID) error {
 	return scanUUID(r, v)
 }
+
+// LogValue implements the [slog.LogValuer] interface.
+// IDs are logged in their string representation, see [NewSlogHandler] for other formats.
+func (r Random[P]) LogValue() slog.Value {
+	return logValue(r)
+}
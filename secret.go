@@ -0,0 +1,197 @@
+package typeid
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/sumup/typeid/base32"
+)
+
+const (
+	secretLen    = 32 // 256 bits of entropy
+	secretStrLen = 52 // base32 of secretLen bytes
+)
+
+// Secret represents a high-entropy secret token, e.g. an API key or a password reset token.
+// It consists of a prefix and 256 random bits, encoded as 52 characters of **lowercase** crockford base32,
+// e.g. apikey_1nr2tzfck92r272yjyqftdsv8bpzv0xzx4jw7msgxwwzydy2ptqk.
+//
+// Unlike the other ID types, Secret is not based on a UUID. Compare secrets with [Secret.Equal], which runs in constant time.
+// Secrets are persisted as their [SecretHash]: when passed to [database/sql] or pgx, e.g. as query argument, they are
+// stored as the bytes of their hash, so that the plaintext token never reaches the database. Scan the stored value into
+// a [SecretHash] and check presented secrets with [Secret.Verify].
+//
+// Secrets are redacted when logged with [log/slog], but [Secret.String] returns the full token.
+type Secret[P Prefix] struct {
+	// _ makes secrets incomparable, so that they cannot be compared with == instead of [Secret.Equal].
+	_     [0]func()
+	token [secretLen]byte
+}
+
+// secretInstance is a helper constraint to infer the prefix of [Secret] types.
+type secretInstance[P Prefix] interface {
+	Secret[P]
+}
+
+// NewSecret returns a new secret of the specified type, generated using [crypto/rand].
+//
+// Example:
+//
+//	type APIKey = typeid.Secret[APIKeyPrefix]
+//	key, err := typeid.NewSecret[APIKey]()
+func NewSecret[T secretInstance[P], P Prefix]() (T, error) {
+	if err := validatePrefix(getPrefix[P]()); err != nil {
+		return T{}, err
+	}
+
+	var s Secret[P]
+	if _, err := rand.Read(s.token[:]); err != nil {
+		return T{}, fmt.Errorf("generate secret: %w", err)
+	}
+	return T(s), nil
+}
+
+// SecretFromString parses a secret of the specified type from its string representation.
+func SecretFromString[T secretInstance[P], P Prefix](s string) (T, error) {
+	prefix := getPrefix[P]()
+	if err := validatePrefix(prefix); err != nil {
+		return T{}, err
+	}
+	if prefix != "" && !strings.HasPrefix(s, prefix+"_") {
+		return T{}, fmt.Errorf("%w: %w for %T, expected %q", ErrParse, ErrInvalidPrefix, T{}, prefix)
+	}
+
+	suffix := strings.TrimPrefix(s, prefix+"_")
+	if len(suffix) != secretStrLen {
+		// Don't include the suffix in the error, as it might be a valid secret of another type.
+		return T{}, fmt.Errorf("%w: %w: suffix length is %d, expected %d", ErrParse, ErrInvalidSuffix, len(suffix), secretStrLen)
+	}
+
+	decoded, err := base32.DecodeLowerBytes(suffix)
+	if err != nil {
+		return T{}, fmt.Errorf("%w: %w: %s", ErrParse, ErrInvalidSuffix, err.Error())
+	}
+
+	var secret Secret[P]
+	copy(secret.token[:], decoded)
+	return T(secret), nil
+}
+
+func (Secret[P]) Type() string {
+	return getPrefix[P]()
+}
+
+// String returns the full secret token including its prefix.
+func (s Secret[P]) String() string {
+	suffix := base32.EncodeLowerBytes(s.token[:])
+	prefix := getPrefix[P]()
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + "_" + suffix
+}
+
+// IsNil reports whether the secret is the zero value.
+func (s Secret[P]) IsNil() bool {
+	return s.token == [secretLen]byte{}
+}
+
+// IsZero reports whether the secret is the zero value.
+// It allows omitting unset secrets with the `json:",omitzero"` struct tag.
+func (s Secret[P]) IsZero() bool {
+	return s.IsNil()
+}
+
+// Equal reports whether two secrets are equal. The comparison runs in constant time.
+func (s Secret[P]) Equal(other Secret[P]) bool {
+	return subtle.ConstantTimeCompare(s.token[:], other.token[:]) == 1
+}
+
+// Hash returns the SHA-256 digest of the secret's string representation.
+// As secrets have 256 bits of entropy, a fast unsalted hash is sufficient to store them.
+func (s Secret[P]) Hash() SecretHash {
+	return SecretHash(sha256.Sum256([]byte(s.String())))
+}
+
+// Verify reports whether the secret matches the given hash. The comparison runs in constant time.
+func (s Secret[P]) Verify(hash SecretHash) bool {
+	sh := s.Hash()
+	return subtle.ConstantTimeCompare(sh[:], hash[:]) == 1
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// Internally it use [Secret.String]
+func (s Secret[P]) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// It parses a secret using [SecretFromString]
+func (s *Secret[P]) UnmarshalText(text []byte) error {
+	var err error
+	*s, err = SecretFromString[Secret[P]](string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal text to typeid.Secret: %w", err)
+	}
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface. It returns the bytes of the [SecretHash] of the secret, not the
+// plaintext token, or NULL for the zero secret.
+func (s Secret[P]) Value() (driver.Value, error) {
+	if s.IsNil() {
+		return nil, nil
+	}
+	return s.BytesValue()
+}
+
+// BytesValue implements the [github.com/jackc/pgx/v5/pgtype.BytesValuer] interface. Like [Secret.Value], it returns the bytes of the
+// [SecretHash] of the secret, or nil for the zero secret.
+func (s Secret[P]) BytesValue() ([]byte, error) {
+	if s.IsNil() {
+		return nil, nil
+	}
+	hash := s.Hash()
+	return hash[:], nil
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+// Secrets are always logged redacted.
+func (s Secret[P]) LogValue() slog.Value {
+	prefix := getPrefix[P]()
+	if prefix == "" {
+		return slog.StringValue(slogRedacted)
+	}
+	return slog.StringValue(prefix + "_" + slogRedacted)
+}
+
+// SecretHash is the SHA-256 digest of a [Secret], see [Secret.Hash].
+// It can be stored in a BYTEA column using [database/sql] or pgx.
+type SecretHash [sha256.Size]byte
+
+// String returns the hex encoding of the hash.
+func (h SecretHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+func (h SecretHash) Value() (driver.Value, error) {
+	return h[:], nil
+}
+
+func (h *SecretHash) Scan(src any) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("scan typeid.SecretHash: espected []byte, got %T", src)
+	}
+	if len(b) != sha256.Size {
+		return fmt.Errorf("scan typeid.SecretHash: invalid length %d, expected %d", len(b), sha256.Size)
+	}
+	copy(h[:], b)
+	return nil
+}
@@ -0,0 +1,161 @@
+package typeid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type apiKeyPrefix struct{}
+
+func (apiKeyPrefix) Prefix() string {
+	return "apikey"
+}
+
+type APIKey = Secret[apiKeyPrefix]
+
+func TestSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("new", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := NewSecret[APIKey]()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if key.IsNil() {
+			t.Errorf("generated secret must not be nil")
+		}
+		if !strings.HasPrefix(key.String(), "apikey_") || len("apikey_")+secretStrLen != len(key.String()) {
+			t.Errorf("unexpected secret format: %s", key)
+		}
+		if key.Equal(Must(NewSecret[APIKey]())) {
+			t.Errorf("generated secrets must differ")
+		}
+	})
+
+	t.Run("from string", func(t *testing.T) {
+		t.Parallel()
+
+		key := Must(NewSecret[APIKey]())
+		parsed, err := SecretFromString[APIKey](key.String())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !key.Equal(parsed) {
+			t.Errorf("parsed secret must equal the original one")
+		}
+
+		for _, invalid := range []string{
+			"user_" + strings.TrimPrefix(key.String(), "apikey_"),
+			"apikey_" + strings.Repeat("0", secretStrLen-1),
+			"apikey_2" + strings.Repeat("0", secretStrLen-1),
+			strings.ToUpper(key.String()),
+		} {
+			if _, err := SecretFromString[APIKey](invalid); !errors.Is(err, ErrParse) {
+				t.Errorf("parsing %s: expected ErrParse, got %v", invalid, err)
+			}
+		}
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		t.Parallel()
+
+		key := Must(NewSecret[APIKey]())
+		hash := key.Hash()
+		if !key.Verify(hash) {
+			t.Errorf("secret must match its hash")
+		}
+		if Must(NewSecret[APIKey]()).Verify(hash) {
+			t.Errorf("other secret must not match the hash")
+		}
+
+		val, err := hash.Value()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		var scanned SecretHash
+		if err := scanned.Scan(val); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if hash != scanned {
+			t.Errorf("scanned hash must equal the original one")
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		key := Must(NewSecret[APIKey]())
+		encoded, err := json.Marshal(key)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+
+		var decoded APIKey
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !key.Equal(decoded) {
+			t.Errorf("decoded secret must equal the original one")
+		}
+	})
+
+	t.Run("persisted as hash", func(t *testing.T) {
+		t.Parallel()
+
+		key := Must(NewSecret[APIKey]())
+		var valuer driver.Valuer = key
+		val, err := valuer.Value()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		var scanned SecretHash
+		if err := scanned.Scan(val); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !key.Verify(scanned) {
+			t.Errorf("secret must be stored as its hash, got %v", val)
+		}
+
+		b, err := key.BytesValue()
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if !bytes.Equal(b, scanned[:]) {
+			t.Errorf("expected the hash %s from BytesValue, got %x", scanned, b)
+		}
+
+		if val, err := (APIKey{}).Value(); val != nil || err != nil {
+			t.Errorf("expected NULL for the zero secret, got %v, %v", val, err)
+		}
+	})
+
+	t.Run("not comparable", func(t *testing.T) {
+		t.Parallel()
+
+		if reflect.TypeFor[APIKey]().Comparable() {
+			t.Errorf("secrets must not be comparable with ==, use Equal instead")
+		}
+	})
+
+	t.Run("redacted in logs", func(t *testing.T) {
+		t.Parallel()
+
+		key := Must(NewSecret[APIKey]())
+		var buf bytes.Buffer
+		slog.New(slog.NewTextHandler(&buf, nil)).Info("test", "key", key)
+		if strings.Contains(buf.String(), key.String()) {
+			t.Errorf("secret must not be logged, got: %s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "key=apikey_<redacted>") {
+			t.Errorf("log output should contain the redacted secret, got: %s", buf.String())
+		}
+	})
+}
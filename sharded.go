@@ -0,0 +1,325 @@
+package typeid
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sumup/typeid/base32"
+)
+
+const (
+	// DefaultShardBits is the number of bits used for the shard of [Sharded] IDs, unless the [Prefix] implements [ShardLayout].
+	DefaultShardBits = 8
+	// MaxShardBits is the maximum number of bits that can be used for the shard of [Sharded] IDs.
+	MaxShardBits = 12
+
+	// versionSharded is the UUID version of [Sharded] IDs (UUIDv8).
+	versionSharded = 8
+)
+
+// ErrShardNotConfigured is returned when generating a [Sharded] ID with [New] before calling [SetLocalShard].
+// Use [NewInShard] to generate IDs without configuring the local shard.
+var ErrShardNotConfigured = errors.New("local shard is not configured, see typeid.SetLocalShard")
+
+// Sharded represents an unique identifier that is k-sortable and contains the shard (or region) it was created in.
+// This allows routing requests to the respective cluster based on the ID alone, see [ShardOf].
+// Internally, it's based on UUIDv8 with the following layout:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                           unix_ts_ms                          |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|          unix_ts_ms           |  ver  |  shard  |    rand     |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|var|                          rand                             |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                              rand                             |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// The shard takes up [DefaultShardBits] bits, unless the [Prefix] implements [ShardLayout].
+// Like [Sortable], the suffix part is encoded in **lowercase** crockford base32. Parsing fails for UUIDs other than UUIDv8,
+// both from strings and with [FromUUID].
+//
+// [New] generates Sharded IDs in the local shard, see [SetLocalShard].
+type Sharded[P Prefix] struct{ typedID[P] }
+
+// ShardLayout can be implemented by a [Prefix] to change the number of bits used for the shard of [Sharded] IDs.
+// The number of bits must be between 1 and [MaxShardBits], the remaining bits are random.
+type ShardLayout interface {
+	ShardBits() int
+}
+
+// localShard is the shard used by [New] to generate [Sharded] IDs.
+var localShard atomic.Pointer[uint16]
+
+// SetLocalShard sets the shard of the local cluster, which is used by [New] to generate [Sharded] IDs.
+// It is usually called once during startup. As the setting is global to the process, prefer [NewInShard] in code
+// generating IDs for several shards, e.g. in tests or when migrating data between shards.
+func SetLocalShard(shard uint16) {
+	localShard.Store(&shard)
+}
+
+// shardedIDProcs contains the processors of [Sharded] IDs, indexed by the number of shard bits.
+var shardedIDProcs = func() [MaxShardBits + 1]*processor {
+	var procs [MaxShardBits + 1]*processor
+	for bits := 1; bits <= MaxShardBits; bits++ {
+		procs[bits] = &processor{
+			b32Encode: func(u uuid.UUID) string {
+				return base32.EncodeLower([16]byte(u))
+			},
+			b32EncodeTo: func(dst []byte, u uuid.UUID) {
+				base32.EncodeLowerTo(dst, [16]byte(u))
+			},
+			b32Decode: func(b []byte) (uuid.UUID, error) {
+				var u uuid.UUID
+				if err := base32.DecodeLowerTo((*[16]byte)(&u), b); err != nil {
+					return uuid.Nil, err
+				}
+				if err := validateShardedUUID(u); err != nil {
+					return uuid.Nil, err
+				}
+				return u, nil
+			},
+			generateUUID: func() (uuid.UUID, error) {
+				shard := localShard.Load()
+				if shard == nil {
+					return uuid.Nil, ErrShardNotConfigured
+				}
+				return newShardedUUID(time.Now(), *shard, bits)
+			},
+			version:       versionSharded,
+			validateUUID:  validateShardedUUID,
+			suffixPattern: lowerSuffixPattern,
+		}
+	}
+	return procs
+}()
+
+// validateShardedUUID rejects UUIDs other than UUIDv8 and the nil UUID.
+func validateShardedUUID(u uuid.UUID) error {
+	if !u.IsNil() && u.Version() != versionSharded {
+		return fmt.Errorf("invalid UUID version %d, expected %d", u.Version(), versionSharded)
+	}
+	return nil
+}
+
+// invalidShardLayoutProc is the processor of [Sharded] IDs with an invalid [ShardLayout].
+var invalidShardLayoutProc = &processor{
+	b32Encode:   shardedIDProcs[DefaultShardBits].b32Encode,
+	b32EncodeTo: shardedIDProcs[DefaultShardBits].b32EncodeTo,
+	b32Decode: func([]byte) (uuid.UUID, error) {
+		return uuid.Nil, errors.New("invalid shard layout")
+	},
+	generateUUID: func() (uuid.UUID, error) {
+		return uuid.Nil, errors.New("invalid shard layout")
+	},
+	version: versionSharded,
+	validateUUID: func(uuid.UUID) error {
+		return errors.New("invalid shard layout")
+	},
+	suffixPattern: lowerSuffixPattern,
+}
+
+// invalidShardLayout describes a [ShardLayout] with the given invalid number of bits.
+func invalidShardLayout(bits int) string {
+	return fmt.Sprintf("invalid shard layout: %d bits, expected between 1 and %d", bits, MaxShardBits)
+}
+
+func newShardedUUID(t time.Time, shard uint16, bits int) (uuid.UUID, error) {
+	if int(shard) >= 1<<bits {
+		return uuid.Nil, fmt.Errorf("shard %d does not fit into %d bits", shard, bits)
+	}
+
+	var u uuid.UUID
+	if _, err := rand.Read(u[6:]); err != nil {
+		return uuid.Nil, err
+	}
+
+	ms := uint64(t.UnixMilli())
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+
+	// The shard takes up the most significant bits of the 12 bits following the version.
+	field := uint16(u[6]&0x0F)<<8 | uint16(u[7])
+	randBits := MaxShardBits - bits
+	field = shard<<randBits | field&(1<<randBits-1)
+	u[6] = byte(field >> 8)
+	u[7] = byte(field)
+
+	u.SetVersion(versionSharded)
+	u.SetVariant(uuid.VariantRFC9562)
+	return u, nil
+}
+
+func getShardBits[P Prefix]() int {
+	var prefix P
+	if l, ok := any(prefix).(ShardLayout); ok {
+		return l.ShardBits()
+	}
+	return DefaultShardBits
+}
+
+// shardedInstance is a helper constraint for functions that only support [Sharded] IDs.
+type shardedInstance[P Prefix] interface {
+	Sharded[P]
+	instance[P]
+}
+
+// NewInShard returns a new [Sharded] ID of the specified type in the given shard.
+// Use [New] to generate IDs in the local shard.
+func NewInShard[T shardedInstance[P], P Prefix](shard uint16) (T, error) {
+	if err := checkPrefix[P](); err != nil {
+		return Nil[T](), err
+	}
+
+	bits := getShardBits[P]()
+	if bits < 1 || bits > MaxShardBits {
+		return Nil[T](), errors.New(invalidShardLayout(bits))
+	}
+
+	u, err := newShardedUUID(time.Now(), shard, bits)
+	if err != nil {
+		return Nil[T](), err
+	}
+	tid, err := newTypedID[P](u)
+	if err != nil {
+		return Nil[T](), err
+	}
+	return T{tid}, nil
+}
+
+// ShardOf returns the shard the given [Sharded] ID was created in.
+// It panics if the [ShardLayout] of the prefix is invalid, as no shard can be read from such IDs.
+func ShardOf[T shardedInstance[P], P Prefix](id T) uint16 {
+	bits := getShardBits[P]()
+	if bits < 1 || bits > MaxShardBits {
+		panic(fmt.Sprintf("typeid: ShardOf %T: %s", id, invalidShardLayout(bits)))
+	}
+
+	u := Sharded[P](id).UUID()
+	field := uint16(u[6]&0x0F)<<8 | uint16(u[7])
+	return field >> (MaxShardBits - bits)
+}
+
+func (Sharded[P]) processor() *processor {
+	bits := getShardBits[P]()
+	if bits < 1 || bits > MaxShardBits {
+		return invalidShardLayoutProc
+	}
+	return shardedIDProcs[bits]
+}
+
+func (Sharded[P]) Type() string {
+	return getPrefix[P]()
+}
+
+func (s Sharded[P]) String() string {
+	return toString[P](s.typedID, s.processor())
+}
+
+func (s Sharded[P]) UUID() uuid.UUID {
+	return s.uuid
+}
+
+// IsNil reports whether the ID is the nil ID, see [Nil].
+func (s Sharded[P]) IsNil() bool {
+	return s.uuid.IsNil()
+}
+
+// IsZero reports whether the ID is the zero value, which is equal to the nil ID.
+// It allows omitting unset IDs with the `json:",omitzero"` struct tag.
+func (s Sharded[P]) IsZero() bool {
+	return s.IsNil()
+}
+
+// Compare returns an integer comparing two IDs, see [Compare].
+// It can be used as a method expression, e.g. with [slices.SortFunc].
+func (s Sharded[P]) Compare(other Sharded[P]) int {
+	return Compare(s, other)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// Internally it use [Sharded.String]
+func (s Sharded[P]) MarshalText() ([]byte, error) {
+	return marshalText(s)
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// It parses a TypeID string using [FromString]
+func (s *Sharded[P]) UnmarshalText(text []byte) error {
+	return unmarshalText(s, text)
+}
+
+func (s Sharded[P]) Value() (driver.Value, error) {
+	return value(s)
+}
+
+func (s *Sharded[P]) Scan(src any) error {
+	return scan(s, src)
+}
+
+func (s Sharded[P]) TextValue() (pgtype.Text, error) {
+	return textValue(s)
+}
+
+func (s *Sharded[P]) ScanText(v pgtype.Text) error {
+	return scanText(s, v)
+}
+
+// ScanBytes implements the [pgtype.BytesScanner] interface.
+func (s *Sharded[P]) ScanBytes(v []byte) error {
+	return scanBytes(s, v)
+}
+
+// MarshalGQL implements the graphql.Marshaler interface of gqlgen, see https://gqlgen.com/reference/scalars/.
+// It writes the ID as string and panics with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+// gqlgen prefers [Sharded.MarshalGQLContext], which returns the error instead.
+func (s Sharded[P]) MarshalGQL(w io.Writer) {
+	mustMarshalGQL(s, w)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen. It accepts strings, which are passed
+// for both custom scalars and the built-in ID type.
+func (s *Sharded[P]) UnmarshalGQL(v any) error {
+	return unmarshalGQL(s, v)
+}
+
+// MarshalGQLContext implements the graphql.ContextMarshaler interface of gqlgen. It writes the ID as string and
+// fails with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+func (s Sharded[P]) MarshalGQLContext(_ context.Context, w io.Writer) error {
+	return marshalGQL(s, w)
+}
+
+// UnmarshalGQLContext implements the graphql.ContextUnmarshaler interface of gqlgen, see [Sharded.UnmarshalGQL].
+func (s *Sharded[P]) UnmarshalGQLContext(_ context.Context, v any) error {
+	return unmarshalGQL(s, v)
+}
+
+func (s Sharded[P]) UUIDValue() (pgtype.UUID, error) {
+	return uuidValue(s)
+}
+
+func (s *Sharded[P]) ScanUUID(v pgtype.UUID) error {
+	return scanUUID(s, v)
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+// IDs are logged in their string representation, see [NewSlogHandler] for other formats.
+func (s Sharded[P]) LogValue() slog.Value {
+	return logValue(s)
+}
@@ -0,0 +1,167 @@
+package typeid
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type regionPrefix struct{}
+
+func (regionPrefix) Prefix() string {
+	return "payment"
+}
+
+func (regionPrefix) ShardBits() int {
+	return 4
+}
+
+type invalidLayoutPrefix struct{}
+
+func (invalidLayoutPrefix) Prefix() string {
+	return "zone"
+}
+
+func (invalidLayoutPrefix) ShardBits() int {
+	return MaxShardBits + 1
+}
+
+type (
+	ShardedID = Sharded[userPrefix]
+	RegionID  = Sharded[regionPrefix]
+)
+
+func TestSharded_New(t *testing.T) {
+	t.Parallel()
+
+	SetLocalShard(42)
+
+	id, err := New[ShardedID]()
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if versionSharded != id.UUID().Version() {
+		t.Errorf("expected UUIDv8, got version byte: %x", id.UUID().Version())
+	}
+	if shard := ShardOf(id); shard != 42 {
+		t.Errorf("expected shard 42, got %d", shard)
+	}
+
+	// 42 does not fit into 4 bits.
+	if _, err := New[RegionID](); err == nil {
+		t.Errorf("expected error for a shard exceeding the shard layout")
+	}
+}
+
+func TestSharded_NewInShard(t *testing.T) {
+	t.Parallel()
+
+	for _, shard := range []uint16{0, 1, 7, 15} {
+		id, err := NewInShard[RegionID](shard)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if shard != ShardOf(id) {
+			t.Errorf("expected shard %d, got %d", shard, ShardOf(id))
+		}
+	}
+
+	if _, err := NewInShard[RegionID](16); err == nil {
+		t.Errorf("expected error for a shard exceeding the shard layout")
+	}
+
+	id := Must(NewInShard[ShardedID](255))
+	if shard := ShardOf(id); shard != 255 {
+		t.Errorf("expected shard 255, got %d", shard)
+	}
+}
+
+func TestSharded_Sortable(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	prev := Nil[ShardedID]()
+	for i := range 100 {
+		// Alternate shards, the creation time takes precedence.
+		u, err := newShardedUUID(start.Add(time.Duration(i)*time.Millisecond), uint16(255-i%2*255), DefaultShardBits)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		id := Must(FromUUID[ShardedID](u))
+		if prev.Compare(id) >= 0 || prev.String() >= id.String() {
+			t.Fatalf("ids must be k-sortable: %s >= %s", prev, id)
+		}
+		prev = id
+	}
+}
+
+func TestSharded_FromString(t *testing.T) {
+	t.Parallel()
+
+	id := Must(NewInShard[ShardedID](3))
+	parsed, err := FromString[ShardedID](id.String())
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if id != parsed {
+		t.Errorf("expected %s, got %s", id, parsed)
+	}
+
+	nilID, err := FromString[ShardedID](Nil[ShardedID]().String())
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if !nilID.IsNil() {
+		t.Errorf("expected nil id, got %s", nilID)
+	}
+
+	if _, err := FromString[ShardedID](MustNew[Sortable[userPrefix]]().String()); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse for UUIDv7, got %v", err)
+	}
+}
+
+func TestSharded_FromUUID(t *testing.T) {
+	t.Parallel()
+
+	id := Must(NewInShard[ShardedID](3))
+	parsed, err := FromUUID[ShardedID](id.UUID())
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if id != parsed {
+		t.Errorf("expected %s, got %s", id, parsed)
+	}
+	if _, err := FromUUID[ShardedID](Nil[ShardedID]().UUID()); err != nil {
+		t.Errorf("unexpected error for the nil UUID:\n%+v", err)
+	}
+
+	v7 := MustNew[Sortable[userPrefix]]().UUID()
+	if _, err := FromUUID[ShardedID](v7); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse for UUIDv7, got %v", err)
+	}
+	if _, err := FromUUIDBytes[ShardedID](v7.Bytes()); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse for UUIDv7 bytes, got %v", err)
+	}
+	var scanned ShardedID
+	if err := scanned.ScanUUID(pgtype.UUID{Bytes: v7, Valid: true}); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse when scanning a UUIDv7, got %v", err)
+	}
+}
+
+func TestSharded_InvalidLayout(t *testing.T) {
+	t.Parallel()
+
+	type ZoneID = Sharded[invalidLayoutPrefix]
+	if _, err := NewInShard[ZoneID](1); err == nil {
+		t.Error("expected an error for an invalid shard layout")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected ShardOf to panic for an invalid shard layout")
+		}
+	}()
+	ShardOf(Nil[ZoneID]())
+}
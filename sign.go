@@ -0,0 +1,128 @@
+package typeid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/sumup/typeid/base32"
+)
+
+const (
+	signatureLen    = 10 // truncated HMAC-SHA256, 80 bits
+	signatureStrLen = 16 // base32 of signatureLen bytes
+)
+
+// ErrInvalidSignature is returned by [Verify] if the signature of an ID does not match any of the keys.
+var ErrInvalidSignature = errors.New("invalid typeid signature")
+
+// Sign returns the signed string representation of the given ID. It consists of the string representation of the ID,
+// followed by an underscore and a truncated HMAC-SHA256 tag in **lowercase** crockford base32,
+// e.g. user_01hf98sp99fs2b4qf2jm11hse4_4ndxrvmn00d1psne.
+//
+// Signed IDs allow rejecting forged or guessed IDs, e.g. in links sent by email, without querying the database.
+// Use [Verify] to parse them.
+func Sign[T IDType[P], P Prefix](id T, key []byte) string {
+	s := id.String()
+	return s + "_" + base32.EncodeLowerBytes(signature(s, key))
+}
+
+// Verify parses an ID of the specified type from a string signed with [Sign]. The signature is checked in constant time
+// against all given keys, allowing to rotate keys. If it does not match any of them, [ErrInvalidSignature] is returned.
+//
+// Example:
+//
+//	userID, err := typeid.Verify[UserID](s, currentKey, previousKey)
+func Verify[T IDType[P], P Prefix](s string, keys ...[]byte) (T, error) {
+	sepIdx := len(s) - signatureStrLen - 1
+	if sepIdx < 0 || s[sepIdx] != '_' {
+		return Nil[T](), fmt.Errorf("%w: missing signature for %T", ErrParse, T{})
+	}
+
+	tag, err := base32.DecodeLowerBytes(s[sepIdx+1:])
+	if err != nil {
+		return Nil[T](), fmt.Errorf("%w: invalid signature: %s", ErrParse, err.Error())
+	}
+
+	idStr := s[:sepIdx]
+	valid := false
+	for _, key := range keys {
+		// Check all keys to not leak the index of the matching key through timing.
+		if hmac.Equal(tag, signature(idStr, key)) {
+			valid = true
+		}
+	}
+	if !valid {
+		return Nil[T](), ErrInvalidSignature
+	}
+
+	return FromString[T](idStr)
+}
+
+func signature(s string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(s))
+	return mac.Sum(nil)[:signatureLen]
+}
+
+// SigningKeyring provides the keys of a [Signed] ID type. The first key is used to sign IDs, while all keys are used to verify them.
+//
+// Example:
+//
+//	type LinkKeyring struct{}
+//
+//	func (LinkKeyring) SigningKeys() [][]byte {
+//	    return [][]byte{currentKey, previousKey}
+//	}
+type SigningKeyring interface {
+	SigningKeys() [][]byte
+}
+
+// Signed wraps an ID to marshal it to and from its signed string representation, see [Sign] and [Verify].
+//
+// Example:
+//
+//	type SignedUserID = typeid.Signed[UserID, UserPrefix, LinkKeyring]
+//
+//	type UnsubscribeLink struct {
+//	    UserID SignedUserID `json:"user_id"`
+//	}
+type Signed[T IDType[P], P Prefix, K SigningKeyring] struct {
+	ID T
+}
+
+// String returns the signed string representation of the ID.
+// If the keyring does not provide any key, it returns an empty string.
+func (s Signed[T, P, K]) String() string {
+	text, err := s.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// It signs the ID with the first key of the keyring using [Sign].
+func (s Signed[T, P, K]) MarshalText() ([]byte, error) {
+	var keyring K
+	keys := keyring.SigningKeys()
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("marshal typeid.Signed: no signing keys provided by %T", keyring)
+	}
+	return []byte(Sign(s.ID, keys[0])), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// It verifies the ID with all keys of the keyring using [Verify].
+func (s *Signed[T, P, K]) UnmarshalText(text []byte) error {
+	var (
+		keyring K
+		err     error
+	)
+	s.ID, err = Verify[T](string(text), keyring.SigningKeys()...)
+	if err != nil {
+		return fmt.Errorf("unmarshal text to typeid.Signed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package typeid
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	currentSigningKey  = []byte("current-signing-key")
+	previousSigningKey = []byte("previous-signing-key")
+)
+
+type testSigningKeyring struct{}
+
+func (testSigningKeyring) SigningKeys() [][]byte {
+	return [][]byte{currentSigningKey, previousSigningKey}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stable signature", func(t *testing.T) {
+		t.Parallel()
+
+		id := Must(FromString[Sortable[userPrefix]]("user_01hf98sp99fs2b4qf2jm11hse4"))
+		if "user_01hf98sp99fs2b4qf2jm11hse4_4ndxrvmn00d1psne" != Sign(id, []byte("key")) {
+			t.Errorf("unexpected signed id: %s", Sign(id, []byte("key")))
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[UserID]()
+		verified, err := Verify[UserID](Sign(id, currentSigningKey), currentSigningKey)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != verified {
+			t.Errorf("expected %s, got %s", id, verified)
+		}
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[AccountID]()
+		verified, err := Verify[AccountID](Sign(id, previousSigningKey), currentSigningKey, previousSigningKey)
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if id != verified {
+			t.Errorf("expected %s, got %s", id, verified)
+		}
+
+		if _, err := Verify[AccountID](Sign(id, previousSigningKey), currentSigningKey); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("forged", func(t *testing.T) {
+		t.Parallel()
+
+		signed := Sign(MustNew[UserID](), currentSigningKey)
+		sig := signed[len(signed)-signatureStrLen:]
+		forged := MustNew[UserID]().String() + "_" + sig
+		if _, err := Verify[UserID](forged, currentSigningKey); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+
+		// Signatures are bound to the ID type.
+		otherType := "system_account" + strings.TrimPrefix(signed, "user")
+		if _, err := Verify[AccountID](otherType, currentSigningKey); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		id := MustNew[UserID]()
+		for _, invalid := range []string{
+			"",
+			id.String(),
+			id.String() + "-" + strings.Repeat("0", signatureStrLen),
+			id.String() + "_" + strings.Repeat("u", signatureStrLen),
+		} {
+			if _, err := Verify[UserID](invalid, currentSigningKey); !errors.Is(err, ErrParse) {
+				t.Errorf("verifying %q: expected ErrParse, got %v", invalid, err)
+			}
+		}
+	})
+}
+
+func TestSigned_JSON(t *testing.T) {
+	t.Parallel()
+
+	type link struct {
+		UserID Signed[UserID, userPrefix, testSigningKeyring] `json:"user_id"`
+	}
+
+	id := MustNew[UserID]()
+	encoded, err := json.Marshal(link{UserID: Signed[UserID, userPrefix, testSigningKeyring]{ID: id}})
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if `{"user_id":"`+Sign(id, currentSigningKey)+`"}` != string(encoded) {
+		t.Errorf("expected signed id, got %s", encoded)
+	}
+
+	var decoded link
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if id != decoded.UserID.ID {
+		t.Errorf("expected %s, got %s", id, decoded.UserID.ID)
+	}
+
+	tampered := strings.Replace(string(encoded), id.String(), MustNew[UserID]().String(), 1)
+	if err := json.Unmarshal([]byte(tampered), &decoded); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package typeid
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// SlogFormat determines how IDs are rendered by a handler created with [NewSlogHandler].
+type SlogFormat int
+
+const (
+	// SlogFormatString logs IDs in their string representation, e.g. user_01hf98sp99fs2b4qf2jm11hse4.
+	SlogFormatString SlogFormat = iota
+	// SlogFormatGroup logs IDs as a group with the fields "prefix", "uuid" and, for IDs based on UUIDv7, "time".
+	SlogFormatGroup
+	// SlogFormatRedacted logs only the prefix of IDs, e.g. user_<redacted>.
+	SlogFormatRedacted
+)
+
+const slogRedacted = "<redacted>"
+
+// SlogHandlerOptions are options for a handler created with [NewSlogHandler].
+type SlogHandlerOptions struct {
+	// Format determines how IDs are logged. Defaults to [SlogFormatString].
+	Format SlogFormat
+	// Redact reports whether IDs with the given prefix are sensitive, e.g. API keys.
+	// Sensitive IDs are always logged in their redacted form, regardless of Format.
+	Redact func(prefix string) bool
+}
+
+// loggableID is implemented by all UUID based ID types of this package.
+type loggableID interface {
+	slog.LogValuer
+	ID
+}
+
+// SlogAttr returns an [slog.Attr] for the given key and ID.
+//
+// Example:
+//
+//	logger.Info("user created", typeid.SlogAttr("user_id", userID))
+func SlogAttr[T IDType[P], P Prefix](key string, id T) slog.Attr {
+	return slog.Any(key, id)
+}
+
+func logValue[T IDType[P], P Prefix](id T) slog.Value {
+	return slog.StringValue(id.String())
+}
+
+// NewSlogHandler returns a [slog.Handler] that rewrites ID attributes according to opts
+// before passing records on to h. If opts is nil, the default options are used.
+//
+// Example:
+//
+//	handler := typeid.NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil), &typeid.SlogHandlerOptions{
+//	    Format: typeid.SlogFormatGroup,
+//	    Redact: func(prefix string) bool { return prefix == "api_key" },
+//	})
+//	logger := slog.New(handler)
+func NewSlogHandler(h slog.Handler, opts *SlogHandlerOptions) slog.Handler {
+	if opts == nil {
+		opts = &SlogHandlerOptions{}
+	}
+	return &slogHandler{next: h, opts: *opts}
+}
+
+type slogHandler struct {
+	next slog.Handler
+	opts SlogHandlerOptions
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(h.replaceAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, record)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	replaced := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		replaced[i] = h.replaceAttr(a)
+	}
+	return &slogHandler{next: h.next.WithAttrs(replaced), opts: h.opts}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+func (h *slogHandler) replaceAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindLogValuer:
+		if id, ok := a.Value.Any().(loggableID); ok {
+			return slog.Attr{Key: a.Key, Value: h.idValue(id)}
+		}
+		// Other values may resolve to groups containing IDs.
+		return h.replaceAttr(slog.Attr{Key: a.Key, Value: a.Value.Resolve()})
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			attrs[i] = h.replaceAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	default:
+		return a
+	}
+}
+
+func (h *slogHandler) idValue(id loggableID) slog.Value {
+	prefix := id.Type()
+	format := h.opts.Format
+	if h.opts.Redact != nil && h.opts.Redact(prefix) {
+		format = SlogFormatRedacted
+	}
+
+	switch format {
+	case SlogFormatGroup:
+		u := id.UUID()
+		attrs := []slog.Attr{
+			slog.String("prefix", prefix),
+			slog.String("uuid", u.String()),
+		}
+		if u.Version() == uuid.V7 {
+			if ts, err := uuid.TimestampFromV7(u); err == nil {
+				if t, err := ts.Time(); err == nil {
+					attrs = append(attrs, slog.Time("time", t))
+				}
+			}
+		}
+		return slog.GroupValue(attrs...)
+	case SlogFormatRedacted:
+		if prefix == "" {
+			return slog.StringValue(slogRedacted)
+		}
+		return slog.StringValue(prefix + "_" + slogRedacted)
+	case SlogFormatString:
+	}
+	return id.LogValue()
+}
@@ -0,0 +1,110 @@
+package typeid
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlog(t *testing.T) {
+	t.Parallel()
+
+	userID := MustNew[UserID]()
+	accountID := Must(FromString[AccountID]("system_account_01hp1aybq6f6athhfcvp1j8fpt"))
+
+	logJSON := func(t *testing.T, opts *SlogHandlerOptions, args ...any) map[string]any {
+		t.Helper()
+		var buf bytes.Buffer
+		logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil), opts))
+		logger.Info("test", args...)
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		return entry
+	}
+
+	t.Run("log valuer without handler", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		slog.New(slog.NewTextHandler(&buf, nil)).Info("test", SlogAttr("user_id", userID))
+		if !strings.Contains(buf.String(), "user_id="+userID.String()) {
+			t.Errorf("log output should contain the string representation of the id, got: %s", buf.String())
+		}
+	})
+
+	t.Run("string format", func(t *testing.T) {
+		t.Parallel()
+
+		entry := logJSON(t, nil, SlogAttr("user_id", userID))
+		if userID.String() != entry["user_id"] {
+			t.Errorf("expected %s, got %v", userID.String(), entry["user_id"])
+		}
+	})
+
+	t.Run("group format", func(t *testing.T) {
+		t.Parallel()
+
+		entry := logJSON(t, &SlogHandlerOptions{Format: SlogFormatGroup},
+			SlogAttr("user_id", userID),
+			slog.Group("nested", SlogAttr("account_id", accountID)),
+		)
+
+		group, ok := entry["user_id"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected a group, got %v", entry["user_id"])
+		}
+		if userIDPrefix != group["prefix"] {
+			t.Errorf("expected prefix %s, got %v", userIDPrefix, group["prefix"])
+		}
+		if userID.UUID().String() != group["uuid"] {
+			t.Errorf("expected uuid %s, got %v", userID.UUID().String(), group["uuid"])
+		}
+		if _, ok := group["time"]; ok {
+			t.Errorf("random ids must not log a time, got %v", group["time"])
+		}
+
+		nested, ok := entry["nested"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected a group, got %v", entry["nested"])
+		}
+		accountGroup, ok := nested["account_id"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected a group, got %v", nested["account_id"])
+		}
+		if "2024-02-07T08:28:55.398Z" != accountGroup["time"] {
+			t.Errorf("expected time of the UUIDv7, got %v", accountGroup["time"])
+		}
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		t.Parallel()
+
+		entry := logJSON(t, &SlogHandlerOptions{
+			Format: SlogFormatGroup,
+			Redact: func(prefix string) bool { return prefix == userIDPrefix },
+		}, SlogAttr("user_id", userID), SlogAttr("account_id", accountID))
+
+		if "user_<redacted>" != entry["user_id"] {
+			t.Errorf("expected redacted id, got %v", entry["user_id"])
+		}
+		if _, ok := entry["account_id"].(map[string]any); !ok {
+			t.Errorf("expected a group for non-sensitive ids, got %v", entry["account_id"])
+		}
+	})
+
+	t.Run("with attrs", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		handler := NewSlogHandler(slog.NewTextHandler(&buf, nil), &SlogHandlerOptions{Format: SlogFormatRedacted})
+		slog.New(handler).With(SlogAttr("user_id", userID)).Info("test")
+		if !strings.Contains(buf.String(), "user_id=user_<redacted>") {
+			t.Errorf("log output should contain the redacted id, got: %s", buf.String())
+		}
+	})
+}
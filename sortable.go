@@ -1,7 +1,10 @@
 package typeid
 
 import (
+	"context"
 	"database/sql/driver"
+	"io"
+	"log/slog"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -20,14 +23,16 @@ var sortableIDProc = &processor{
 	b32EncodeTo: func(dst []byte, u uuid.UUID) {
 		base32.EncodeLowerTo(dst, [16]byte(u))
 	},
-	b32Decode: func(s string) (uuid.UUID, error) {
-		decoded, err := base32.DecodeLower(s)
-		if err != nil {
+	b32Decode: func(b []byte) (uuid.UUID, error) {
+		var u uuid.UUID
+		if err := base32.DecodeLowerTo((*[16]byte)(&u), b); err != nil {
 			return uuid.Nil, err
 		}
-		return uuid.FromBytes(decoded)
+		return u, nil
 	},
-	generateUUID: uuid.NewV7,
+	generateUUID:  uuid.NewV7,
+	version:       uuid.V7,
+	suffixPattern: lowerSuffixPattern,
 }
 
 func (Sortable[P]) processor() *processor {
@@ -39,13 +44,30 @@ func (Sortable[P]) Type() string {
 }
 
 func (s Sortable[P]) String() string {
-	return toString[P](s.uuid, s.processor())
+	return toString[P](s.typedID, s.processor())
 }
 
 func (r Sortable[P]) UUID() uuid.UUID {
 	return r.uuid
 }
 
+// IsNil reports whether the ID is the nil ID, see [Nil].
+func (s Sortable[P]) IsNil() bool {
+	return s.uuid.IsNil()
+}
+
+// IsZero reports whether the ID is the zero value, which is equal to the nil ID.
+// It allows omitting unset IDs with the `json:",omitzero"` struct tag.
+func (s Sortable[P]) IsZero() bool {
+	return s.IsNil()
+}
+
+// Compare returns an integer comparing two IDs, see [Compare].
+// It can be used as a method expression, e.g. with [slices.SortFunc].
+func (s Sortable[P]) Compare(other Sortable[P]) int {
+	return Compare(s, other)
+}
+
 // MarshalText implements the [encoding.TextMarshaler] interface.
 // Internally it use [Random.String]
 func (r Sortable[P]) MarshalText() ([]byte, error) {
@@ -74,6 +96,35 @@ func (s *Sortable[P]) ScanText(v pgtype.Text) error {
 	return scanText(s, v)
 }
 
+// ScanBytes implements the [pgtype.BytesScanner] interface.
+func (s *Sortable[P]) ScanBytes(v []byte) error {
+	return scanBytes(s, v)
+}
+
+// MarshalGQL implements the graphql.Marshaler interface of gqlgen, see https://gqlgen.com/reference/scalars/.
+// It writes the ID as string and panics with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+// gqlgen prefers [Sortable.MarshalGQLContext], which returns the error instead.
+func (s Sortable[P]) MarshalGQL(w io.Writer) {
+	mustMarshalGQL(s, w)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen. It accepts strings, which are passed
+// for both custom scalars and the built-in ID type.
+func (s *Sortable[P]) UnmarshalGQL(v any) error {
+	return unmarshalGQL(s, v)
+}
+
+// MarshalGQLContext implements the graphql.ContextMarshaler interface of gqlgen. It writes the ID as string and
+// fails with [ErrNilID] for nil IDs of prefixes requiring [NilMarshalError].
+func (s Sortable[P]) MarshalGQLContext(_ context.Context, w io.Writer) error {
+	return marshalGQL(s, w)
+}
+
+// UnmarshalGQLContext implements the graphql.ContextUnmarshaler interface of gqlgen, see [Sortable.UnmarshalGQL].
+func (s *Sortable[P]) UnmarshalGQLContext(_ context.Context, v any) error {
+	return unmarshalGQL(s, v)
+}
+
 func (s Sortable[P]) UUIDValue() (pgtype.UUID, error) {
 	return uuidValue(s)
 }
@@ -81,3 +132,9 @@ func (s Sortable[P]) UUIDValue() (pgtype.UUID, error) {
 func (s *Sortable[P]) ScanUUID(v pgtype.UUID) error {
 	return scanUUID(s, v)
 }
+
+// LogValue implements the [slog.LogValuer] interface.
+// IDs are logged in their string representation, see [NewSlogHandler] for other formats.
+func (s Sortable[P]) LogValue() slog.Value {
+	return logValue(s)
+}
@@ -3,13 +3,19 @@ package typeid
 import (
 	"errors"
 	"fmt"
-	"strings"
+	"reflect"
+	"sync"
+	"unsafe"
 
 	"github.com/gofrs/uuid/v5"
 )
 
 var (
 	ErrParse = errors.New("parse typeid")
+	// ErrInvalidPrefix is returned by parsing functions alongside [ErrParse] if the prefix does not match the ID type.
+	ErrInvalidPrefix = errors.New("invalid prefix")
+	// ErrInvalidSuffix is returned by parsing functions alongside [ErrParse] if the suffix is malformed.
+	ErrInvalidSuffix = errors.New("invalid suffix")
 )
 
 const (
@@ -24,6 +30,8 @@ type Prefix interface {
 
 type typedID[P Prefix] struct {
 	uuid uuid.UUID
+	// env is the index of the environment of the ID, see [EnvironmentQualifier].
+	env uint8
 }
 
 func getPrefix[P Prefix]() string {
@@ -31,11 +39,61 @@ func getPrefix[P Prefix]() string {
 	return prefix.Prefix()
 }
 
+// prefixChecks caches the results of [checkPrefix] by prefix type.
+var prefixChecks sync.Map
+
+// checkPrefix validates the prefix P along with its aliases and environments, see [ValidatePrefix], [PrefixAliaser]
+// and [EnvironmentQualifier]. As they are constant, the result is cached for each prefix type.
+func checkPrefix[P Prefix]() error {
+	key := reflect.TypeFor[P]()
+	if cached, ok := prefixChecks.Load(key); ok {
+		err, _ := cached.(error)
+		return err
+	}
+
+	prefix := getPrefix[P]()
+	err := validatePrefix(prefix)
+	if err == nil && prefix != "" {
+		err = validatePrefixAliases(prefix, getPrefixAliases[P]())
+	}
+	if err == nil {
+		err = validateEnvironments(prefix, getEnvironments[P]())
+	}
+	if err == nil {
+		registerPrefixes(append([]string{prefix}, getPrefixAliases[P]()...)...)
+	}
+	prefixChecks.Store(key, err)
+	return err
+}
+
 type instance[P Prefix] interface {
 	~struct{ typedID[P] }
 	processor() *processor
 }
 
+// ID is implemented by all UUID based ID types of this package, i.e. [Random], [Sortable], [Deterministic] and [Sharded].
+// Use it for code that handles IDs of any type, e.g. logging or metrics.
+type ID interface {
+	Type() string
+	String() string
+	UUID() uuid.UUID
+	IsNil() bool
+}
+
+// IDType is a constraint satisfied by all UUID based ID types with the prefix P. Use it to write generic code over
+// any ID type, which can call the functions of this package, e.g. [New] or [FromString].
+//
+// Example:
+//
+//	func Find[T typeid.IDType[P], P typeid.Prefix](ctx context.Context, s string) (Entity, error) {
+//	    id, err := typeid.FromString[T](s)
+//	    ...
+//	}
+type IDType[P Prefix] interface {
+	instance[P]
+	ID
+}
+
 func (tid typedID[P]) Prefix() string {
 	return getPrefix[P]()
 }
@@ -53,7 +111,7 @@ func (tid typedID[P]) UUID() string {
 //	type UserID = typeid.Sortable[UserPrefix]
 //	id, err := typeid.New[UserID]()
 func New[T instance[P], P Prefix]() (T, error) {
-	tid, err := generate[P](T{}.processor())
+	tid, err := generate[P](T{}.processor(), currentEnvironment.Load())
 	return T{tid}, err
 }
 
@@ -85,26 +143,93 @@ func MustNew[T instance[P], P Prefix]() T {
 }
 
 func FromString[T instance[P], P Prefix](s string) (T, error) {
+	return FromBytes[T](unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+// FromBytes parses a TypeID of the specified type from its textual representation in b, e.g. a JSON value or a database
+// column. Unlike converting b to a string and calling [FromString], it does not allocate.
+//
+// Example:
+//
+//	id, err := typeid.FromBytes[UserID]([]byte("user_01hf98sp99fs2b4qf2jm11hse4"))
+func FromBytes[T instance[P], P Prefix](b []byte) (T, error) {
+	return fromBytes[T](b, currentEnvironment.Load())
+}
+
+// fromBytes parses an ID in the environment env, see [checkEnvironment].
+func fromBytes[T instance[P], P Prefix](b []byte, env *string) (T, error) {
+	if err := checkPrefix[P](); err != nil {
+		return Nil[T](), err
+	}
 	prefix := getPrefix[P]()
-	if prefix != "" && !strings.HasPrefix(s, prefix+"_") {
-		return Nil[T](), fmt.Errorf("%w: invalid prefix for %T, expected %q", ErrParse, T{}, prefix)
+
+	suffix := b
+	var alias string
+	switch {
+	case hasPrefix(b, prefix):
+		suffix = b[len(prefix)+1:]
+	case prefix != "":
+		var ok bool
+		if suffix, alias, ok = trimPrefixAlias[P](b); !ok {
+			return Nil[T](), fmt.Errorf("%w: %w for %T, expected %q", ErrParse, ErrInvalidPrefix, T{}, prefix)
+		}
 	}
 
-	suffix := strings.TrimPrefix(s, prefix+"_")
+	qualifier, qualified, err := splitEnvironment[P](suffix)
+	if err != nil {
+		return Nil[T](), fmt.Errorf("%w: %w", ErrParse, err)
+	}
+	if len(qualified) < len(suffix) {
+		// The qualified prefix must not be the prefix of another ID type, see [EnvironmentQualifier].
+		if qualifiedPrefix := b[:len(b)-len(qualified)-1]; isKnownPrefix(qualifiedPrefix) {
+			return Nil[T](), fmt.Errorf("%w: %w", ErrParse, qualifierCollision(prefix, getEnvironments[P]()[qualifier], string(qualifiedPrefix)))
+		}
+	}
+	suffix = qualified
 
 	tid, err := from[P](suffix, T{}.processor())
 	if err != nil {
-		return Nil[T](), fmt.Errorf("%w: invalid suffix %q: %s", ErrParse, suffix, err.Error())
+		return Nil[T](), fmt.Errorf("%w: %w %q: %s", ErrParse, ErrInvalidSuffix, suffix, err.Error())
+	}
+	// Nil IDs have no environment, even if qualified.
+	if !tid.uuid.IsNil() {
+		tid.env = qualifier
+		if err := checkEnvironment(tid, env); err != nil {
+			return Nil[T](), fmt.Errorf("%w: %w", ErrParse, err)
+		}
+	}
+	if alias != "" {
+		callPrefixAliasHook[P](alias)
 	}
 	return T{tid}, nil
 }
 
+// hasPrefix reports whether b starts with prefix followed by an underscore.
+func hasPrefix[S ~string | ~[]byte](b S, prefix string) bool {
+	return len(b) > len(prefix) && string(b[:len(prefix)]) == prefix && b[len(prefix)] == '_'
+}
+
+// FromUUID returns the ID of the specified type with the given UUID. As UUIDs carry no environment, IDs of types with
+// an [EnvironmentQualifier] are created in the current environment, see [SetEnvironment] and [FromUUIDIn].
+// This applies to all functions restoring IDs from UUIDs, e.g. ScanUUID.
 func FromUUID[T instance[P], P Prefix](u uuid.UUID) (T, error) {
-	if err := validatePrefix(getPrefix[P]()); err != nil {
+	return fromUUID[T](u, currentEnvironment.Load())
+}
+
+func fromUUID[T instance[P], P Prefix](u uuid.UUID, env *string) (T, error) {
+	if err := checkPrefix[P](); err != nil {
 		return Nil[T](), err
 	}
-	// TODO: Add UUID validation for specific type
-	return T{typedID[P]{u}}, nil
+	if validate := (T{}).processor().validateUUID; validate != nil {
+		if err := validate(u); err != nil {
+			return Nil[T](), fmt.Errorf("%w: %w", ErrParse, err)
+		}
+	}
+	tid, err := newTypedIDIn[P](u, env)
+	if err != nil {
+		return Nil[T](), err
+	}
+	return T{tid}, nil
 }
 
 func FromUUIDStr[T instance[P], P Prefix](uuidStr string) (T, error) {
@@ -1,8 +1,10 @@
 package typeid
 
 import (
+	"errors"
 	"math/rand"
 	"reflect"
+	"strconv"
 	"testing"
 	"testing/quick"
 
@@ -69,6 +71,13 @@ func TestTypeID_Nil(t *testing.T) {
 		t.Errorf("two nil id's are equal\nGot: %v\nExpected: %v", nilUserID, Nil[UserID]())
 	}
 
+	if !nilUserID.IsNil() || !nilUserID.IsZero() {
+		t.Errorf("nil id must report being nil")
+	}
+	if MustNew[UserID]().IsNil() {
+		t.Errorf("generated id must not report being nil")
+	}
+
 	nilAccountID := Nil[AccountID]()
 	if "system_account_"+emptyID != nilAccountID.String() {
 		t.Errorf("expected nil account id, got: %s", nilAccountID.String())
@@ -89,11 +98,54 @@ func TestTypeID_ToFrom(t *testing.T) {
 	type SortableID = AccountID
 	t.Run("typeid.Sortable", runToFromQuickTests[SortableID])
 
+	type DeterministicID = Deterministic[userPrefix]
+	t.Run("typeid.Deterministic", runToFromQuickTests[DeterministicID])
+
 	type EmptyPrefixID = NilID
 	t.Run("empty prefix", runToFromQuickTests[EmptyPrefixID])
 }
 
-func runToFromQuickTests[T idImplementation[P], P Prefix](t *testing.T) {
+func TestTypeID_FromBytes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{"", "user", "user01hf98sp99fs2b4qf2jm11hse4", "usr_01hf98sp99fs2b4qf2jm11hse4"} {
+			if _, err := FromBytes[AccountID]([]byte(input)); !errors.Is(err, ErrInvalidPrefix) {
+				t.Errorf("parsing %q: expected ErrInvalidPrefix, got %v", input, err)
+			}
+		}
+	})
+
+	t.Run("invalid suffix", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{"user_", "user_01hf98sp99fs2b4qf2jm11hse", "user_81hf98sp99fs2b4qf2jm11hse4", "user_01hf98sp99fs2b4qf2jm11hseu"} {
+			if _, err := FromBytes[UserID]([]byte(input)); !errors.Is(err, ErrInvalidSuffix) {
+				t.Errorf("parsing %q: expected ErrInvalidSuffix, got %v", input, err)
+			}
+		}
+	})
+}
+
+// TestTypeID_FromBytes_Allocs cannot run in parallel, as other tests would distort the number of allocations.
+func TestTypeID_FromBytes_Allocs(t *testing.T) {
+	b := []byte(MustNew[AccountID]().String())
+	var id AccountID
+	allocs := testing.AllocsPerRun(100, func() {
+		//nolint:errcheck // Only the allocations are of interest.
+		id.UnmarshalText(b)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+	if id.String() != string(b) {
+		t.Errorf("expected %s, got %s", b, id)
+	}
+}
+
+func runToFromQuickTests[T IDType[P], P Prefix](t *testing.T) {
 	t.Helper()
 	t.Parallel()
 
@@ -104,6 +156,13 @@ func runToFromQuickTests[T idImplementation[P], P Prefix](t *testing.T) {
 		}
 	})
 
+	t.Run("from bytes", func(t *testing.T) {
+		t.Parallel()
+		if err := quick.Check(fromBytesTester[T](t), nil); err != nil {
+			t.Error(err)
+		}
+	})
+
 	t.Run("from UUID", func(t *testing.T) {
 		t.Parallel()
 		if err := quick.Check(fromUUIDTester[T](t), nil); err != nil {
@@ -126,7 +185,7 @@ func runToFromQuickTests[T idImplementation[P], P Prefix](t *testing.T) {
 	})
 }
 
-func fromStringTester[T idImplementation[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
+func fromStringTester[T IDType[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
 	t.Helper()
 	return func(wid wrappedID[T, P]) bool {
 		parsedID, err := FromString[T](wid.ID().String())
@@ -137,7 +196,18 @@ func fromStringTester[T idImplementation[P], P Prefix](t *testing.T) func(wid wr
 	}
 }
 
-func fromUUIDTester[T idImplementation[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
+func fromBytesTester[T IDType[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
+	t.Helper()
+	return func(wid wrappedID[T, P]) bool {
+		parsedID, err := FromBytes[T]([]byte(wid.ID().String()))
+		if err != nil {
+			t.Fatalf("parse type id from bytes: unexpected error:\n%+v", err)
+		}
+		return wid.ID() == parsedID
+	}
+}
+
+func fromUUIDTester[T IDType[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
 	t.Helper()
 	return func(wid wrappedID[T, P]) bool {
 		parsedID, err := FromUUID[T](wid.ID().UUID())
@@ -148,7 +218,7 @@ func fromUUIDTester[T idImplementation[P], P Prefix](t *testing.T) func(wid wrap
 	}
 }
 
-func fromUUIDStringTester[T idImplementation[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
+func fromUUIDStringTester[T IDType[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
 	t.Helper()
 	return func(wid wrappedID[T, P]) bool {
 		parsedID, err := FromUUIDStr[T](wid.ID().UUID().String())
@@ -159,7 +229,7 @@ func fromUUIDStringTester[T idImplementation[P], P Prefix](t *testing.T) func(wi
 	}
 }
 
-func fromUUIDBytesTester[T idImplementation[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
+func fromUUIDBytesTester[T IDType[P], P Prefix](t *testing.T) func(wid wrappedID[T, P]) bool {
 	t.Helper()
 	return func(wid wrappedID[T, P]) bool {
 		parsedID, err := FromUUIDBytes[T](wid.id.UUID().Bytes())
@@ -182,11 +252,15 @@ func (w wrappedID[T, P]) ID() T {
 
 func (w wrappedID[T, P]) Generate(rnd *rand.Rand, _ int) reflect.Value {
 	// gen the processor to determine the UUID version to use
+	version := uuid.V5
 	procGenUUID, err := (T{}).processor().generateUUID()
-	if err != nil {
+	switch {
+	case errors.Is(err, ErrNameBased):
+	case err != nil:
 		panic(err)
+	default:
+		version = procGenUUID.Version()
 	}
-	version := procGenUUID.Version()
 
 	uuidGen := uuid.NewGenWithOptions(uuid.WithRandomReader(rnd))
 	var uid uuid.UUID
@@ -196,6 +270,8 @@ func (w wrappedID[T, P]) Generate(rnd *rand.Rand, _ int) reflect.Value {
 		if uid, err = uuidGen.NewV4(); err != nil {
 			panic("failed to generate uuid v4")
 		}
+	case uuid.V5:
+		uid = uuidGen.NewV5(uuid.NamespaceOID, strconv.Itoa(rnd.Int()))
 	case uuid.V7:
 		if uid, err = uuidGen.NewV7(); err != nil {
 			panic("failed to generate uuid v7")
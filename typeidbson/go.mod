@@ -0,0 +1,17 @@
+module github.com/sumup/typeid/typeidbson
+
+go 1.24.0
+
+require (
+	github.com/sumup/typeid v0.0.0
+	go.mongodb.org/mongo-driver/v2 v2.5.0
+)
+
+require (
+	github.com/gofrs/uuid/v5 v5.4.0 // indirect
+	github.com/jackc/pgx/v5 v5.8.0 // indirect
+)
+
+// This module is developed alongside the typeid module and built against the local one. Modules depending on this
+// module ignore the replacement and use the version of the typeid module they require themselves.
+replace github.com/sumup/typeid => ../
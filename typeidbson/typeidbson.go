@@ -0,0 +1,198 @@
+// Package typeidbson marshals typed IDs to and from BSON for storage in MongoDB.
+//
+// IDs are stored either in their string representation or as UUIDs, i.e. BSON binary values of subtype 4.
+// Both forms are decoded regardless of the chosen [Format], which allows to migrate between them.
+//
+// Wrap IDs with [String] or [UUID] to choose the form per field:
+//
+//	type Order struct {
+//	    ID typeidbson.UUID[OrderID, OrderPrefix] `bson:"_id"`
+//	}
+//
+// Or register the ID types with the registry of the client, to use them directly:
+//
+//	reg := bson.NewRegistry()
+//	typeidbson.Register[OrderID](reg, typeidbson.FormatUUID)
+//	client, err := mongo.Connect(options.Client().ApplyURI(uri).SetRegistry(reg))
+package typeidbson
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/sumup/typeid"
+)
+
+// ErrUnsupportedType is returned when decoding a BSON value, which is neither a string nor a UUID.
+var ErrUnsupportedType = errors.New("unsupported BSON type")
+
+// Format is the BSON representation of IDs.
+type Format int
+
+const (
+	// FormatString stores IDs in their string representation, see [encoding.TextMarshaler].
+	FormatString Format = iota
+	// FormatUUID stores IDs as BSON binary values of the UUID subtype 4.
+	FormatUUID
+)
+
+// String wraps an ID to marshal it to a BSON string. It unmarshals BSON strings and UUIDs.
+type String[T typeid.IDType[P], P typeid.Prefix] struct {
+	ID T
+}
+
+// MarshalBSONValue implements the [bson.ValueMarshaler] interface.
+func (s String[T, P]) MarshalBSONValue() (byte, []byte, error) {
+	return marshalValue[T](s.ID, FormatString)
+}
+
+// UnmarshalBSONValue implements the [bson.ValueUnmarshaler] interface.
+func (s *String[T, P]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalValue[T](&s.ID, bson.RawValue{Type: bson.Type(typ), Value: data})
+}
+
+// UUID wraps an ID to marshal it to a BSON binary value of the UUID subtype 4. It unmarshals BSON strings and UUIDs.
+type UUID[T typeid.IDType[P], P typeid.Prefix] struct {
+	ID T
+}
+
+// MarshalBSONValue implements the [bson.ValueMarshaler] interface.
+func (u UUID[T, P]) MarshalBSONValue() (byte, []byte, error) {
+	return marshalValue[T](u.ID, FormatUUID)
+}
+
+// UnmarshalBSONValue implements the [bson.ValueUnmarshaler] interface.
+func (u *UUID[T, P]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalValue[T](&u.ID, bson.RawValue{Type: bson.Type(typ), Value: data})
+}
+
+// Register registers an encoder and a decoder for the ID type T with the registry. IDs are encoded in the given
+// format and decoded from BSON strings and UUIDs.
+func Register[T typeid.IDType[P], P typeid.Prefix](reg *bson.Registry, format Format) {
+	t := reflect.TypeFor[T]()
+	reg.RegisterTypeEncoder(t, bson.ValueEncoderFunc(func(_ bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+		id, ok := val.Interface().(T)
+		if !ok {
+			return bson.ValueEncoderError{Name: "typeidbson.Register", Types: []reflect.Type{t}, Received: val}
+		}
+		switch format {
+		case FormatString:
+			text, err := marshalText[T](id)
+			if err != nil {
+				return err
+			}
+			return vw.WriteString(text)
+		case FormatUUID:
+			u := id.UUID()
+			return vw.WriteBinaryWithSubtype(u[:], bson.TypeBinaryUUID)
+		default:
+			return fmt.Errorf("marshal %T: unknown format %d", id, format)
+		}
+	}))
+	reg.RegisterTypeDecoder(t, bson.ValueDecoderFunc(func(_ bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+		if !val.CanSet() || val.Type() != t {
+			return bson.ValueDecoderError{Name: "typeidbson.Register", Types: []reflect.Type{t}, Received: val}
+		}
+
+		var id T
+		var err error
+		switch vr.Type() {
+		case bson.TypeString:
+			var s string
+			if s, err = vr.ReadString(); err != nil {
+				return err
+			}
+			err = unmarshalText(&id, s)
+		case bson.TypeBinary:
+			var data []byte
+			var subtype byte
+			if data, subtype, err = vr.ReadBinary(); err != nil {
+				return err
+			}
+			id, err = fromBinary[T](subtype, data)
+		case bson.TypeNull:
+			err = vr.ReadNull()
+		default:
+			err = fmt.Errorf("unmarshal %T: %w %s", id, ErrUnsupportedType, vr.Type())
+		}
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(id))
+		return nil
+	}))
+}
+
+func marshalValue[T typeid.IDType[P], P typeid.Prefix](id T, format Format) (byte, []byte, error) {
+	var typ bson.Type
+	var data []byte
+	var err error
+	switch format {
+	case FormatString:
+		var text string
+		if text, err = marshalText[T](id); err != nil {
+			return 0, nil, err
+		}
+		typ, data, err = bson.MarshalValue(text)
+	case FormatUUID:
+		u := id.UUID()
+		typ, data, err = bson.MarshalValue(bson.Binary{Subtype: bson.TypeBinaryUUID, Data: u[:]})
+	default:
+		err = fmt.Errorf("marshal %T: unknown format %d", id, format)
+	}
+	return byte(typ), data, err
+}
+
+func unmarshalValue[T typeid.IDType[P], P typeid.Prefix](dst *T, v bson.RawValue) error {
+	var err error
+	switch v.Type {
+	case bson.TypeString:
+		s, ok := v.StringValueOK()
+		if !ok {
+			return fmt.Errorf("unmarshal %T: invalid BSON string", *dst)
+		}
+		err = unmarshalText(dst, s)
+	case bson.TypeBinary:
+		subtype, data, ok := v.BinaryOK()
+		if !ok {
+			return fmt.Errorf("unmarshal %T: invalid BSON binary", *dst)
+		}
+		*dst, err = fromBinary[T](subtype, data)
+	case bson.TypeNull:
+		*dst = typeid.Nil[T]()
+	default:
+		err = fmt.Errorf("unmarshal %T: %w %s", *dst, ErrUnsupportedType, v.Type)
+	}
+	return err
+}
+
+// marshalText returns the string representation of the ID, honoring the [typeid.NilMarshaler] of its prefix.
+func marshalText[T typeid.IDType[P], P typeid.Prefix](id T) (string, error) {
+	m, ok := any(id).(encoding.TextMarshaler)
+	if !ok {
+		return id.String(), nil
+	}
+	text, err := m.MarshalText()
+	return string(text), err
+}
+
+// unmarshalText parses the string representation of an ID, honoring the [typeid.NilMarshaler] of its prefix.
+func unmarshalText[T typeid.IDType[P], P typeid.Prefix](dst *T, s string) error {
+	if u, ok := any(dst).(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	var err error
+	*dst, err = typeid.FromString[T](s)
+	return err
+}
+
+func fromBinary[T typeid.IDType[P], P typeid.Prefix](subtype byte, data []byte) (T, error) {
+	if subtype != bson.TypeBinaryUUID {
+		return typeid.Nil[T](), fmt.Errorf("unmarshal %T: %w binary subtype %d", typeid.Nil[T](), ErrUnsupportedType, subtype)
+	}
+	return typeid.FromUUIDBytes[T](data)
+}
@@ -0,0 +1,185 @@
+package typeidbson_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/typeidbson"
+)
+
+type orderPrefix struct{}
+
+func (orderPrefix) Prefix() string {
+	return "order"
+}
+
+type OrderID = typeid.Sortable[orderPrefix]
+
+type keyPrefix struct{}
+
+func (keyPrefix) Prefix() string {
+	return "key"
+}
+
+type KeyID = typeid.Random[keyPrefix]
+
+type document struct {
+	Order typeidbson.String[OrderID, orderPrefix] `bson:"order"`
+	Key   typeidbson.UUID[KeyID, keyPrefix]       `bson:"key"`
+}
+
+func TestMarshalBSONValue(t *testing.T) {
+	t.Parallel()
+
+	doc := document{
+		Order: typeidbson.String[OrderID, orderPrefix]{ID: typeid.MustNew[OrderID]()},
+		Key:   typeidbson.UUID[KeyID, keyPrefix]{ID: typeid.MustNew[KeyID]()},
+	}
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+
+	raw := bson.Raw(b)
+	if s, ok := raw.Lookup("order").StringValueOK(); !ok || s != doc.Order.ID.String() {
+		t.Errorf("expected order to be stored as string %s, got %s", doc.Order.ID, raw.Lookup("order"))
+	}
+	subtype, data, ok := raw.Lookup("key").BinaryOK()
+	if !ok || subtype != bson.TypeBinaryUUID || string(data) != string(doc.Key.ID.UUID().Bytes()) {
+		t.Errorf("expected key to be stored as UUID %s, got %s", doc.Key.ID.UUID(), raw.Lookup("key"))
+	}
+
+	var decoded document
+	if err := bson.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if decoded != doc {
+		t.Errorf("expected %+v, got %+v", doc, decoded)
+	}
+}
+
+func TestUnmarshalBSONValue(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[OrderID]()
+	for _, tt := range []struct {
+		name        string
+		value       any
+		expected    OrderID
+		expectedErr error
+	}{
+		{
+			name:     "string",
+			value:    id.String(),
+			expected: id,
+		},
+		{
+			name:     "uuid",
+			value:    bson.Binary{Subtype: bson.TypeBinaryUUID, Data: id.UUID().Bytes()},
+			expected: id,
+		},
+		{
+			name:     "null",
+			value:    nil,
+			expected: typeid.Nil[OrderID](),
+		},
+		{
+			name:        "invalid string",
+			value:       "user_01hf98sp99fs2b4qf2jm11hse4",
+			expectedErr: typeid.ErrInvalidPrefix,
+		},
+		{
+			name:        "generic binary",
+			value:       bson.Binary{Subtype: bson.TypeBinaryGeneric, Data: id.UUID().Bytes()},
+			expectedErr: typeidbson.ErrUnsupportedType,
+		},
+		{
+			name:        "int",
+			value:       int32(1),
+			expectedErr: typeidbson.ErrUnsupportedType,
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := bson.Marshal(bson.D{{Key: "id", Value: tc.value}})
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+
+			var asString struct {
+				ID typeidbson.String[OrderID, orderPrefix] `bson:"id"`
+			}
+			var asUUID struct {
+				ID typeidbson.UUID[OrderID, orderPrefix] `bson:"id"`
+			}
+			errString := bson.Unmarshal(b, &asString)
+			errUUID := bson.Unmarshal(b, &asUUID)
+			if tc.expectedErr != nil {
+				if !errors.Is(errString, tc.expectedErr) || !errors.Is(errUUID, tc.expectedErr) {
+					t.Errorf("expected %v, got %v and %v", tc.expectedErr, errString, errUUID)
+				}
+				return
+			}
+			if errString != nil || errUUID != nil {
+				t.Fatalf("unexpected errors:\n%+v\n%+v", errString, errUUID)
+			}
+			if asString.ID.ID != tc.expected || asUUID.ID.ID != tc.expected {
+				t.Errorf("expected %s, got %s and %s", tc.expected, asString.ID.ID, asUUID.ID.ID)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		ID  OrderID `bson:"_id"`
+		Key KeyID   `bson:"key"`
+	}
+
+	for _, tt := range []struct {
+		name     string
+		format   typeidbson.Format
+		expected bson.Type
+	}{
+		{name: "string", format: typeidbson.FormatString, expected: bson.TypeString},
+		{name: "uuid", format: typeidbson.FormatUUID, expected: bson.TypeBinary},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg := bson.NewRegistry()
+			typeidbson.Register[OrderID](reg, tc.format)
+			typeidbson.Register[KeyID](reg, tc.format)
+
+			doc := order{ID: typeid.MustNew[OrderID](), Key: typeid.MustNew[KeyID]()}
+			var buf bytes.Buffer
+			enc := bson.NewEncoder(bson.NewDocumentWriter(&buf))
+			enc.SetRegistry(reg)
+			if err := enc.Encode(doc); err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if typ := bson.Raw(buf.Bytes()).Lookup("_id").Type; typ != tc.expected {
+				t.Errorf("expected BSON type %s, got %s", tc.expected, typ)
+			}
+
+			var decoded order
+			dec := bson.NewDecoder(bson.NewDocumentReader(&buf))
+			dec.SetRegistry(reg)
+			if err := dec.Decode(&decoded); err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if decoded != doc {
+				t.Errorf("expected %+v, got %+v", doc, decoded)
+			}
+		})
+	}
+}
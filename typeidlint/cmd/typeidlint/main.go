@@ -0,0 +1,18 @@
+// Command typeidlint reports invalid and duplicate typeid prefixes and UUIDs passed between different ID types.
+// See the typeidlint package for details.
+//
+// Usage:
+//
+//	go get -tool github.com/sumup/typeid/typeidlint/cmd/typeidlint
+//	go tool typeidlint ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/sumup/typeid/typeidlint"
+)
+
+func main() {
+	singlechecker.Main(typeidlint.Analyzer)
+}
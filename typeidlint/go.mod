@@ -0,0 +1,20 @@
+module github.com/sumup/typeid/typeidlint
+
+go 1.24.0
+
+require (
+	github.com/sumup/typeid v0.0.0
+	golang.org/x/tools v0.38.0
+)
+
+require (
+	github.com/gofrs/uuid/v5 v5.4.0 // indirect
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/jackc/pgx/v5 v5.8.0 // indirect
+	golang.org/x/mod v0.29.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+)
+
+// This module is developed alongside the typeid module and built against the local one. Modules depending on this
+// module ignore the replacement and use the version of the typeid module they require themselves.
+replace github.com/sumup/typeid => ../
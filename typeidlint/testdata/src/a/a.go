@@ -0,0 +1,83 @@
+package a // want package:`prefixes\(a.AccountPrefix="account", a.UserPrefix="user"\)`
+
+import (
+	"os"
+
+	"github.com/sumup/typeid"
+)
+
+type UserPrefix struct{}
+
+func (UserPrefix) Prefix() string {
+	return "user"
+}
+
+const accountPrefix = "account"
+
+type AccountPrefix struct{}
+
+func (AccountPrefix) Prefix() string {
+	return accountPrefix
+}
+
+type InvalidPrefix struct{}
+
+func (InvalidPrefix) Prefix() string {
+	return "invalid-prefix" // want `invalid prefix: 'invalid-prefix', prefix should match \[a-z_\]\{0,63\}`
+}
+
+type EnvPrefix struct{}
+
+func (EnvPrefix) Prefix() string {
+	return os.Getenv("PREFIX") // want `Prefix method of EnvPrefix must return a constant`
+}
+
+type BranchPrefix struct{}
+
+func (BranchPrefix) Prefix() string { // want `Prefix method of BranchPrefix must return a constant`
+	if os.Getenv("PREFIX") != "" {
+		return "branch"
+	}
+	return "fallback"
+}
+
+type DuplicatePrefix struct{}
+
+func (DuplicatePrefix) Prefix() string { // want `duplicate prefix "user" of a.DuplicatePrefix, already declared by a.UserPrefix`
+	return "user"
+}
+
+// NotAPrefix is no prefix type, as its Prefix method has a different signature.
+type NotAPrefix struct{}
+
+func (NotAPrefix) Prefix(s string) string {
+	return s
+}
+
+// Locale is no prefix type, as it is never used as type argument for a prefix.
+type Locale struct {
+	tag string
+}
+
+func (l Locale) Prefix() string {
+	return l.tag
+}
+
+type (
+	UserID         = typeid.Random[UserPrefix]
+	SortableUserID = typeid.Sortable[UserPrefix]
+	AccountID      = typeid.Random[AccountPrefix]
+	InvalidID      = typeid.Random[InvalidPrefix]
+	EnvID          = typeid.Sortable[EnvPrefix]
+	BranchID       = typeid.Sortable[BranchPrefix]
+	DuplicateID    = typeid.Random[DuplicatePrefix]
+)
+
+func convert(userID UserID, accountID AccountID) {
+	_, _ = typeid.FromUUID[UserID](userID.UUID())
+	_, _ = typeid.FromUUID[UserID](accountID.UUID())                // want `UUID of AccountID passed to FromUUID of UserID, use typeid.Retype to convert IDs explicitly`
+	_, _ = typeid.FromUUIDStr[AccountID](userID.UUID().String())    // want `UUID of UserID passed to FromUUIDStr of AccountID, use typeid.Retype`
+	_, _ = typeid.FromUUIDBytes[AccountID]((userID.UUID()).Bytes()) // want `passed to FromUUIDBytes`
+	_, _ = typeid.FromUUID[SortableUserID](userID.UUID())           // want `use typeid.ConvertKind to convert IDs explicitly`
+	_, _ = typeid.FromUUIDStr[AccountID](accountID.UUID().String())
+}
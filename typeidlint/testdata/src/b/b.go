@@ -0,0 +1,17 @@
+package b // want package:`prefixes\(\)`
+
+import (
+	"a"
+
+	"github.com/sumup/typeid"
+)
+
+type MemberPrefix struct{}
+
+func (MemberPrefix) Prefix() string { // want `duplicate prefix "user" of b.MemberPrefix, already declared by a.UserPrefix`
+	return "user"
+}
+
+type MemberID = typeid.Random[MemberPrefix]
+
+var _ a.UserID
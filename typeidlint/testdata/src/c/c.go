@@ -0,0 +1,11 @@
+package c
+
+import "github.com/sumup/typeid"
+
+type OrderPrefix struct{}
+
+func (OrderPrefix) Prefix() string {
+	return "order"
+}
+
+type OrderID = typeid.Sortable[OrderPrefix]
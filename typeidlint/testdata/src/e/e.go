@@ -0,0 +1,14 @@
+package e // want `duplicate prefix "order" of d.OrderPrefix, already declared by c.OrderPrefix`
+
+import (
+	"c"
+	"d"
+
+	"github.com/sumup/typeid"
+)
+
+var (
+	_ c.OrderID
+	_ d.OrderID
+	_ typeid.Prefix
+)
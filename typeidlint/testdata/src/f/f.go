@@ -0,0 +1,22 @@
+package f // want package:`prefixes\(\)`
+
+import "os"
+
+// TagPrefix, LabelPrefix and NotePrefix are declared without importing the typeid package.
+type TagPrefix struct{}
+
+func (TagPrefix) Prefix() string {
+	return os.Getenv("PREFIX")
+}
+
+type LabelPrefix struct{}
+
+func (LabelPrefix) Prefix() string {
+	return "Label"
+}
+
+type NotePrefix struct{}
+
+func (NotePrefix) Prefix() string {
+	return "note"
+}
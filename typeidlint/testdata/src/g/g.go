@@ -0,0 +1,17 @@
+package g // want package:`prefixes\(f.NotePrefix="note"\)`
+
+import (
+	"f"
+
+	"github.com/sumup/typeid"
+)
+
+type (
+	TagID   = typeid.Random[f.TagPrefix]     // want `prefix type f.TagPrefix: Prefix method of TagPrefix must return a constant`
+	LabelID = typeid.Sortable[f.LabelPrefix] // want `prefix type f.LabelPrefix: invalid prefix: 'Label'`
+	NoteID  = typeid.Random[f.NotePrefix]
+)
+
+func parse(s string) (NoteID, error) {
+	return typeid.FromUUIDStr[NoteID](s)
+}
@@ -0,0 +1,48 @@
+// Package typeid is a stub of the typeid package for the tests of the analyzer.
+package typeid
+
+type Prefix interface {
+	Prefix() string
+}
+
+type UUID [16]byte
+
+func (u UUID) String() string { return "" }
+
+func (u UUID) Bytes() []byte { return u[:] }
+
+type typedID[P Prefix] struct {
+	uuid UUID
+}
+
+func (tid typedID[P]) Prefix() string {
+	var prefix P
+	return prefix.Prefix()
+}
+
+type instance[P Prefix] interface {
+	~struct{ typedID[P] }
+}
+
+type Random[P Prefix] struct{ typedID[P] }
+
+func (r Random[P]) UUID() UUID { return r.uuid }
+
+type Sortable[P Prefix] struct{ typedID[P] }
+
+func (s Sortable[P]) UUID() UUID { return s.uuid }
+
+func FromUUID[T instance[P], P Prefix](u UUID) (T, error) {
+	var id T
+	return id, nil
+}
+
+func FromUUIDStr[T instance[P], P Prefix](s string) (T, error) {
+	var id T
+	return id, nil
+}
+
+func FromUUIDBytes[T instance[P], P Prefix](b []byte) (T, error) {
+	var id T
+	return id, nil
+}
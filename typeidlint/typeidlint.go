@@ -0,0 +1,429 @@
+// Package typeidlint provides an analyzer reporting invalid prefix declarations and misuse of typed IDs.
+//
+// The analyzer detects prefix types by their use as type argument for a type parameter constrained by [typeid.Prefix],
+// e.g. in typeid.Sortable[UserPrefix] or typeid.New[UserID], so that other types with a Prefix method are ignored.
+// It reports
+//   - Prefix methods of prefix types that do not return a constant,
+//   - constant prefixes, which are invalid according to [typeid.ValidatePrefix],
+//   - duplicate prefixes within a package and its dependencies,
+//   - the UUID of an ID passed to [typeid.FromUUID], [typeid.FromUUIDStr] or [typeid.FromUUIDBytes] of a different ID type,
+//     which should be converted explicitly with [typeid.Retype] or [typeid.ConvertKind] instead.
+//
+// Prefix types may be declared in packages that do not use them as type arguments. Their Prefix methods are then
+// reported in the first package using them. As analyzers only see the dependencies of a package, duplicate prefixes
+// in packages that do not import each other are reported in the first package importing both, e.g. the main package.
+//
+// Add the typeidlint command as tool to run the analyzer:
+//
+//	go get -tool github.com/sumup/typeid/typeidlint/cmd/typeidlint
+//	go tool typeidlint ./...
+package typeidlint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/sumup/typeid"
+)
+
+const typeidPath = "github.com/sumup/typeid"
+
+// Analyzer reports invalid prefix declarations and misuse of typed IDs.
+var Analyzer = &analysis.Analyzer{
+	Name:      "typeidlint",
+	Doc:       "report invalid and duplicate typeid prefixes and UUIDs passed between different ID types",
+	URL:       "https://pkg.go.dev/github.com/sumup/typeid/typeidlint",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(prefixesFact)},
+}
+
+// prefixesFact describes the prefix types of a package.
+type prefixesFact struct {
+	// Prefixes maps the valid constant prefixes of the prefix types used by the package to their qualified names.
+	Prefixes map[string]string
+	// Methods maps the qualified names of the types with a Prefix method declared by the package to the result of
+	// checking the method, so that dependent packages can check the prefix types they use.
+	Methods map[string]prefixMethod
+	// Checked lists the qualified names of the prefix types, which were checked while analyzing the package.
+	Checked map[string]bool
+}
+
+func (*prefixesFact) AFact() {}
+
+// String lists the prefixes of the fact, which are compared in the tests of the analyzer.
+func (f *prefixesFact) String() string {
+	prefixes := make([]string, 0, len(f.Prefixes))
+	for prefix, name := range f.Prefixes {
+		prefixes = append(prefixes, fmt.Sprintf("%s=%q", name, prefix))
+	}
+	sort.Strings(prefixes)
+	return "prefixes(" + strings.Join(prefixes, ", ") + ")"
+}
+
+// prefixMethod is the result of checking a Prefix method.
+type prefixMethod struct {
+	// Prefix is the constant prefix returned by the method, if valid.
+	Prefix string
+	// Err describes why the method is invalid, if it is.
+	Err string
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result of %s", inspect.Analyzer.Name)
+	}
+
+	used := usedPrefixTypes(pass)
+	checked := checkedByDeps(pass)
+	declared := depPrefixes(pass)
+	fact := &prefixesFact{
+		Prefixes: make(map[string]string),
+		Methods:  make(map[string]prefixMethod),
+		Checked:  make(map[string]bool),
+	}
+	check := func(name string, m prefixMethod, pos token.Pos, foreign bool) {
+		fact.Checked[name] = true
+		switch {
+		case m.Err != "" && foreign:
+			pass.Reportf(pos, "prefix type %s: %s", name, m.Err)
+			return
+		case m.Err != "":
+			pass.Reportf(pos, "%s", m.Err)
+			return
+		}
+		if other, ok := declared[m.Prefix]; ok && other != name {
+			pass.Reportf(pos, "duplicate prefix %q of %s, already declared by %s", m.Prefix, name, other)
+			return
+		}
+		declared[m.Prefix] = name
+		fact.Prefixes[m.Prefix] = name
+	}
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.CallExpr)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			tn, m, pos, ok := checkPrefixMethod(pass, n)
+			if !ok {
+				return
+			}
+			name := qualifiedName(tn)
+			fact.Methods[name] = m
+			if _, ok := used[tn]; ok && !checked[name] {
+				check(name, m, pos, false)
+			}
+		case *ast.CallExpr:
+			checkFromUUID(pass, n)
+		}
+	})
+
+	// Check the prefix types declared by dependencies, which have not been checked yet.
+	deps := make([]*types.TypeName, 0, len(used))
+	for tn := range used {
+		if tn.Pkg() != pass.Pkg && !checked[qualifiedName(tn)] {
+			deps = append(deps, tn)
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		return used[deps[i]] < used[deps[j]]
+	})
+	for _, tn := range deps {
+		var depFact prefixesFact
+		if !pass.ImportPackageFact(tn.Pkg(), &depFact) {
+			continue
+		}
+		name := qualifiedName(tn)
+		if m, ok := depFact.Methods[name]; ok {
+			check(name, m, used[tn], true)
+		}
+	}
+
+	if len(fact.Prefixes) > 0 || len(fact.Methods) > 0 || len(fact.Checked) > 0 {
+		pass.ExportPackageFact(fact)
+	}
+	return nil, nil
+}
+
+// usedPrefixTypes returns the named types used as type argument for a type parameter constrained by [typeid.Prefix],
+// mapped to the position of their first use.
+func usedPrefixTypes(pass *analysis.Pass) map[*types.TypeName]token.Pos {
+	used := make(map[*types.TypeName]token.Pos)
+	for ident, inst := range pass.TypesInfo.Instances {
+		var params *types.TypeParamList
+		switch obj := pass.TypesInfo.Uses[ident].(type) {
+		case *types.TypeName:
+			if generic, ok := obj.Type().(interface{ TypeParams() *types.TypeParamList }); ok {
+				params = generic.TypeParams()
+			}
+		case *types.Func:
+			if sig, ok := obj.Type().(*types.Signature); ok {
+				params = sig.TypeParams()
+			}
+		}
+		if params == nil || params.Len() != inst.TypeArgs.Len() {
+			continue
+		}
+
+		for i := range params.Len() {
+			if !isPrefixConstraint(params.At(i).Constraint()) {
+				continue
+			}
+			named, ok := types.Unalias(inst.TypeArgs.At(i)).(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			tn := named.Obj()
+			if pos, ok := used[tn]; !ok || ident.Pos() < pos {
+				used[tn] = ident.Pos()
+			}
+		}
+	}
+	return used
+}
+
+// isPrefixConstraint reports whether t is the [typeid.Prefix] interface.
+func isPrefixConstraint(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Name() == "Prefix" && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == typeidPath
+}
+
+// checkedByDeps returns the prefix types checked while analyzing the dependencies of the package.
+func checkedByDeps(pass *analysis.Pass) map[string]bool {
+	checked := make(map[string]bool)
+	for _, f := range pass.AllPackageFacts() {
+		if fact, ok := f.Fact.(*prefixesFact); ok && f.Package != pass.Pkg {
+			for name := range fact.Checked {
+				checked[name] = true
+			}
+		}
+	}
+	return checked
+}
+
+func qualifiedName(tn *types.TypeName) string {
+	return tn.Pkg().Path() + "." + tn.Name()
+}
+
+// depPrefixes returns the prefixes declared by the dependencies of the package. Duplicates among dependencies, which
+// have not been reported by another dependency, are reported at the package clause of the first file.
+func depPrefixes(pass *analysis.Pass) map[string]string {
+	facts := pass.AllPackageFacts()
+	sort.Slice(facts, func(i, j int) bool {
+		return facts[i].Package.Path() < facts[j].Package.Path()
+	})
+
+	prefixes := make(map[string]string)
+	for _, f := range facts {
+		fact, ok := f.Fact.(*prefixesFact)
+		if !ok || f.Package == pass.Pkg {
+			continue
+		}
+		for prefix, name := range fact.Prefixes {
+			other, ok := prefixes[prefix]
+			if !ok || other == name {
+				prefixes[prefix] = name
+				continue
+			}
+			if !reportedByImport(pass.Pkg, pkgPath(name), pkgPath(other)) && len(pass.Files) > 0 {
+				pass.Reportf(pass.Files[0].Name.Pos(), "duplicate prefix %q of %s, already declared by %s", prefix, name, other)
+			}
+		}
+	}
+	return prefixes
+}
+
+// pkgPath returns the package path of a qualified type name.
+func pkgPath(name string) string {
+	return name[:strings.LastIndex(name, ".")]
+}
+
+// reportedByImport reports whether a package imported by pkg depends on both packages a and b, in which case
+// duplicate prefixes of a and b have been reported while analyzing the imported package already.
+func reportedByImport(pkg *types.Package, a, b string) bool {
+	for _, imp := range pkg.Imports() {
+		if dependsOn(imp, a, map[*types.Package]bool{}) && dependsOn(imp, b, map[*types.Package]bool{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOn reports whether pkg is or transitively imports the package with the given path.
+func dependsOn(pkg *types.Package, path string, seen map[*types.Package]bool) bool {
+	if pkg.Path() == path {
+		return true
+	}
+	if seen[pkg] {
+		return false
+	}
+	seen[pkg] = true
+	for _, imp := range pkg.Imports() {
+		if dependsOn(imp, path, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPrefixMethod checks a Prefix method and returns its receiver type, the result of the check and the position of
+// the diagnostic of an invalid method.
+func checkPrefixMethod(pass *analysis.Pass, decl *ast.FuncDecl) (*types.TypeName, prefixMethod, token.Pos, bool) {
+	if decl.Recv == nil || decl.Name.Name != "Prefix" || decl.Body == nil {
+		return nil, prefixMethod{}, token.NoPos, false
+	}
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil, prefixMethod{}, token.NoPos, false
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Params().Len() != 0 || sig.Results().Len() != 1 || !types.Identical(sig.Results().At(0).Type(), types.Typ[types.String]) {
+		return nil, prefixMethod{}, token.NoPos, false
+	}
+
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := types.Unalias(recv).(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		// Generic types like the ID types of the typeid package merely forward their prefix.
+		return nil, prefixMethod{}, token.NoPos, false
+	}
+	tn := named.Obj()
+	notConstant := prefixMethod{Err: fmt.Sprintf("Prefix method of %s must return a constant", tn.Name())}
+
+	if len(decl.Body.List) != 1 {
+		return tn, notConstant, decl.Name.Pos(), true
+	}
+	ret, ok := decl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return tn, notConstant, decl.Name.Pos(), true
+	}
+	tv, ok := pass.TypesInfo.Types[ret.Results[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return tn, notConstant, ret.Results[0].Pos(), true
+	}
+
+	prefix := constant.StringVal(tv.Value)
+	if err := typeid.ValidatePrefix(prefix); err != nil {
+		return tn, prefixMethod{Err: err.Error()}, ret.Results[0].Pos(), true
+	}
+	return tn, prefixMethod{Prefix: prefix}, decl.Name.Pos(), true
+}
+
+// fromUUIDFuncs maps the functions of the typeid package creating IDs from UUIDs to the methods of [uuid.UUID], which
+// may be applied to the UUID of an ID before passing it.
+var fromUUIDFuncs = map[string]string{
+	"FromUUID":      "",
+	"FromUUIDStr":   "String",
+	"FromUUIDBytes": "Bytes",
+}
+
+// checkFromUUID reports calls like typeid.FromUUID[AccountID](userID.UUID()).
+func checkFromUUID(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+
+	fun := ast.Unparen(call.Fun)
+	if idx, ok := fun.(*ast.IndexExpr); ok {
+		fun = idx.X
+	} else if idx, ok := fun.(*ast.IndexListExpr); ok {
+		fun = idx.X
+	}
+	var ident *ast.Ident
+	switch fun := fun.(type) {
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.Ident:
+		ident = fun
+	default:
+		return
+	}
+
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != typeidPath {
+		return
+	}
+	uuidMethod, ok := fromUUIDFuncs[fn.Name()]
+	if !ok {
+		return
+	}
+	inst, ok := pass.TypesInfo.Instances[ident]
+	if !ok || inst.TypeArgs.Len() == 0 {
+		return
+	}
+	target := inst.TypeArgs.At(0)
+
+	// Unwrap id.UUID(), id.UUID().String() or id.UUID().Bytes().
+	arg := ast.Unparen(call.Args[0])
+	if uuidMethod != "" {
+		var ok bool
+		if arg, ok = methodCallReceiver(arg, uuidMethod); !ok {
+			return
+		}
+	}
+	src, ok := methodCallReceiver(arg, "UUID")
+	if !ok {
+		return
+	}
+	srcType := pass.TypesInfo.TypeOf(src)
+	if srcType == nil || !isIDType(srcType) || types.Identical(srcType, target) {
+		return
+	}
+
+	conversion := "typeid.Retype"
+	if samePrefix(srcType, target) {
+		conversion = "typeid.ConvertKind"
+	}
+	pass.Reportf(call.Pos(), "UUID of %s passed to %s of %s, use %s to convert IDs explicitly",
+		types.TypeString(srcType, types.RelativeTo(pass.Pkg)), fn.Name(), types.TypeString(target, types.RelativeTo(pass.Pkg)), conversion)
+}
+
+// methodCallReceiver returns x for an expression x.method().
+func methodCallReceiver(expr ast.Expr, method string) (ast.Expr, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return nil, false
+	}
+	return sel.X, true
+}
+
+// isIDType reports whether t is an instance of one of the UUID based ID types of the typeid package.
+func isIDType(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != typeidPath {
+		return false
+	}
+	switch named.Obj().Name() {
+	case "Random", "Sortable", "Deterministic", "Sharded":
+		return named.TypeArgs().Len() == 1
+	}
+	return false
+}
+
+func samePrefix(a, b types.Type) bool {
+	na, ok := types.Unalias(a).(*types.Named)
+	if !ok {
+		return false
+	}
+	nb, ok := types.Unalias(b).(*types.Named)
+	if !ok || na.TypeArgs().Len() != 1 || nb.TypeArgs().Len() != 1 {
+		return false
+	}
+	return types.Identical(na.TypeArgs().At(0), nb.TypeArgs().At(0))
+}
@@ -0,0 +1,15 @@
+package typeidlint_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/sumup/typeid/typeidlint"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), typeidlint.Analyzer, "a", "b", "e", "f", "g")
+}
@@ -0,0 +1,24 @@
+module github.com/sumup/typeid/typeidpb
+
+go 1.24.0
+
+require (
+	github.com/google/cel-go v0.26.1
+	github.com/sumup/typeid v0.0.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	cel.dev/expr v0.24.0 // indirect
+	github.com/antlr4-go/antlr/v4 v4.13.0 // indirect
+	github.com/gofrs/uuid/v5 v5.4.0 // indirect
+	github.com/jackc/pgx/v5 v5.8.0 // indirect
+	github.com/stoewer/go-strcase v1.2.0 // indirect
+	golang.org/x/exp v0.0.0-20230515195305-f3d0a9c9a5cc // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240826202546-f6391c0de4c7 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240826202546-f6391c0de4c7 // indirect
+)
+
+// This module is developed alongside the typeid module and built against the local one. Modules depending on this
+// module ignore the replacement and use the version of the typeid module they require themselves.
+replace github.com/sumup/typeid => ../
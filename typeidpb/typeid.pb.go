@@ -0,0 +1,139 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: typeidpb/typeid.proto
+
+package typeidpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TypeID is a typed ID, e.g. user_01hf98sp99fs2b4qf2jm11hse4.
+//
+// The type of the ID is defined by the field it is used in. Use protovalidate rules to restrict a field to the
+// IDs of a type.
+type TypeID struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The string representation of the ID, including its prefix. May be empty if uuid is set.
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The 16 bytes of the UUID of the ID. Optional, if set together with value, both must denote the same ID.
+	Uuid          []byte `protobuf:"bytes,2,opt,name=uuid,proto3,oneof" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeID) Reset() {
+	*x = TypeID{}
+	mi := &file_typeidpb_typeid_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeID) ProtoMessage() {}
+
+func (x *TypeID) ProtoReflect() protoreflect.Message {
+	mi := &file_typeidpb_typeid_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeID.ProtoReflect.Descriptor instead.
+func (*TypeID) Descriptor() ([]byte, []int) {
+	return file_typeidpb_typeid_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TypeID) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TypeID) GetUuid() []byte {
+	if x != nil {
+		return x.Uuid
+	}
+	return nil
+}
+
+var File_typeidpb_typeid_proto protoreflect.FileDescriptor
+
+const file_typeidpb_typeid_proto_rawDesc = "" +
+	"\n" +
+	"\x15typeidpb/typeid.proto\x12\x0fsumup.typeid.v1\"@\n" +
+	"\x06TypeID\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x17\n" +
+	"\x04uuid\x18\x02 \x01(\fH\x00R\x04uuid\x88\x01\x01B\a\n" +
+	"\x05_uuidB\"Z github.com/sumup/typeid/typeidpbb\x06proto3"
+
+var (
+	file_typeidpb_typeid_proto_rawDescOnce sync.Once
+	file_typeidpb_typeid_proto_rawDescData []byte
+)
+
+func file_typeidpb_typeid_proto_rawDescGZIP() []byte {
+	file_typeidpb_typeid_proto_rawDescOnce.Do(func() {
+		file_typeidpb_typeid_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_typeidpb_typeid_proto_rawDesc), len(file_typeidpb_typeid_proto_rawDesc)))
+	})
+	return file_typeidpb_typeid_proto_rawDescData
+}
+
+var file_typeidpb_typeid_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_typeidpb_typeid_proto_goTypes = []any{
+	(*TypeID)(nil), // 0: sumup.typeid.v1.TypeID
+}
+var file_typeidpb_typeid_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_typeidpb_typeid_proto_init() }
+func file_typeidpb_typeid_proto_init() {
+	if File_typeidpb_typeid_proto != nil {
+		return
+	}
+	file_typeidpb_typeid_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_typeidpb_typeid_proto_rawDesc), len(file_typeidpb_typeid_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_typeidpb_typeid_proto_goTypes,
+		DependencyIndexes: file_typeidpb_typeid_proto_depIdxs,
+		MessageInfos:      file_typeidpb_typeid_proto_msgTypes,
+	}.Build()
+	File_typeidpb_typeid_proto = out.File
+	file_typeidpb_typeid_proto_goTypes = nil
+	file_typeidpb_typeid_proto_depIdxs = nil
+}
@@ -0,0 +1,172 @@
+// Package typeidpb provides the [TypeID] protobuf message to pass typed IDs in gRPC APIs, converters from and to the
+// ID types of the typeid package and protovalidate rules restricting fields to the IDs of a type.
+//
+// Import typeidpb/typeid.proto, with the root of this module in the import path, and use the message instead of bare
+// string fields:
+//
+//	import "typeidpb/typeid.proto";
+//
+//	message GetUserRequest {
+//	  sumup.typeid.v1.TypeID user_id = 1 [(buf.validate.field).cel = {
+//	    id: "typeid.user"
+//	    message: "must be a valid user ID"
+//	    expression: "(this.value == '' ? has(this.uuid) : this.value.matches('^user_[0-7][0-9a-hjkmnp-tv-z]{25}$')) && (!has(this.uuid) || size(this.uuid) == 16)"
+//	  }];
+//	}
+//
+// The rules are generated with [MessageRule] and [StringRule]. Then convert the IDs in the handlers:
+//
+//	userID, err := typeidpb.FromProto[UserID](req.GetUserId())
+package typeidpb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=module=github.com/sumup/typeid ../typeidpb/typeid.proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sumup/typeid"
+)
+
+// ErrUUIDMismatch is returned by [FromProto] if the value and the UUID of a message denote different IDs.
+var ErrUUIDMismatch = errors.New("typeid value and uuid mismatch")
+
+// ToProto returns the message of the ID in its string form. Nil IDs are converted according to the
+// [typeid.NilMarshaling] of the prefix, so that [FromProto] accepts the message: it fails with [typeid.ErrNilID] for
+// [typeid.NilMarshalError] and returns a nil message, i.e. an unset field, for [typeid.NilMarshalEmpty].
+//
+// Example:
+//
+//	msg, err := typeidpb.ToProto(user.ID)
+func ToProto[T typeid.IDType[P], P typeid.Prefix](id T) (*TypeID, error) {
+	if unset, err := nilMessage(id); unset || err != nil {
+		return nil, err
+	}
+	return &TypeID{Value: id.String()}, nil
+}
+
+// ToProtoWithUUID returns the message of the ID in its string form and the bytes of its UUID,
+// which spares clients without typeid support parsing the suffix. Nil IDs are converted like by [ToProto].
+func ToProtoWithUUID[T typeid.IDType[P], P typeid.Prefix](id T) (*TypeID, error) {
+	if unset, err := nilMessage(id); unset || err != nil {
+		return nil, err
+	}
+	return &TypeID{Value: id.String(), Uuid: id.UUID().Bytes()}, nil
+}
+
+// nilMessage applies the [typeid.NilMarshaling] of P to the message of the ID. It reports whether the message is unset.
+func nilMessage[T typeid.IDType[P], P typeid.Prefix](id T) (bool, error) {
+	if !id.IsNil() {
+		return false, nil
+	}
+	switch nilMarshaling[P]() {
+	case typeid.NilMarshalError:
+		return false, fmt.Errorf("to proto %T: %w", id, typeid.ErrNilID)
+	case typeid.NilMarshalEmpty:
+		return true, nil
+	case typeid.NilMarshalDefault:
+	}
+	return false, nil
+}
+
+// FromProto returns the ID of the specified type of the message. It parses the value with [typeid.FromString] or,
+// if the value is empty, the UUID with [typeid.FromUUIDBytes]. A nil message, i.e. an unset field, fails with
+// [typeid.ErrNilID], unless the prefix marshals nil IDs to empty strings with [typeid.NilMarshalEmpty]. Then it results
+// in the nil ID, see [typeid.Nil].
+//
+// Example:
+//
+//	userID, err := typeidpb.FromProto[UserID](req.GetUserId())
+func FromProto[T typeid.IDType[P], P typeid.Prefix](m *TypeID) (T, error) {
+	if m == nil {
+		if nilMarshaling[P]() == typeid.NilMarshalEmpty {
+			return typeid.Nil[T](), nil
+		}
+		return typeid.Nil[T](), fmt.Errorf("from proto %T: %w", typeid.Nil[T](), typeid.ErrNilID)
+	}
+	if m.GetValue() == "" && m.Uuid != nil {
+		return typeid.FromUUIDBytes[T](m.GetUuid())
+	}
+
+	id, err := typeid.FromString[T](m.GetValue())
+	if err != nil {
+		return typeid.Nil[T](), err
+	}
+	if m.Uuid != nil && !bytes.Equal(m.GetUuid(), id.UUID().Bytes()) {
+		return typeid.Nil[T](), fmt.Errorf("%w: %s is not %x", ErrUUIDMismatch, id, m.GetUuid())
+	}
+	return id, nil
+}
+
+// nilMarshaling returns the [typeid.NilMarshaling] of the prefix P.
+func nilMarshaling[P typeid.Prefix]() typeid.NilMarshaling {
+	var prefix P
+	if m, ok := any(prefix).(typeid.NilMarshaler); ok {
+		return m.NilMarshaling()
+	}
+	return typeid.NilMarshalDefault
+}
+
+// Rule is a protovalidate CEL rule, see https://buf.build/docs/protovalidate/.
+type Rule struct {
+	// ID is the identifier of the rule, which is reported in violations.
+	ID string
+	// Message is the error message reported in violations.
+	Message string
+	// Expression is the CEL expression of the rule.
+	Expression string
+}
+
+// String returns the field option of the rule, which can be pasted into a proto file.
+func (r Rule) String() string {
+	var sb strings.Builder
+	sb.WriteString("(buf.validate.field).cel = {\n")
+	sb.WriteString("  id: " + strconv.Quote(r.ID) + "\n")
+	sb.WriteString("  message: " + strconv.Quote(r.Message) + "\n")
+	sb.WriteString("  expression: " + strconv.Quote(r.Expression) + "\n")
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// MessageRule returns the rule restricting a [TypeID] field to IDs of the specified type. The value must match
+// [typeid.Pattern] of the type, unless it is empty and the UUID is set instead. A set UUID must have 16 bytes.
+func MessageRule[T typeid.IDType[P], P typeid.Prefix]() Rule {
+	return Rule{
+		ID:      ruleID[T](),
+		Message: ruleMessage[T](),
+		Expression: "(this.value == '' ? has(this.uuid) : this.value.matches(" + celQuote(typeid.Pattern[T]()) + "))" +
+			" && (!has(this.uuid) || size(this.uuid) == 16)",
+	}
+}
+
+// StringRule returns the rule restricting a string field to IDs of the specified type, i.e. to strings matching
+// [typeid.Pattern] of the type. Use it to validate existing string fields before migrating them to [TypeID].
+func StringRule[T typeid.IDType[P], P typeid.Prefix]() Rule {
+	return Rule{
+		ID:         ruleID[T](),
+		Message:    ruleMessage[T](),
+		Expression: "this.matches(" + celQuote(typeid.Pattern[T]()) + ")",
+	}
+}
+
+func ruleID[T typeid.IDType[P], P typeid.Prefix]() string {
+	if prefix := typeid.Nil[T]().Type(); prefix != "" {
+		return "typeid." + prefix
+	}
+	return "typeid"
+}
+
+func ruleMessage[T typeid.IDType[P], P typeid.Prefix]() string {
+	if prefix := typeid.Nil[T]().Type(); prefix != "" {
+		return "must be a valid " + prefix + " ID"
+	}
+	return "must be a valid ID"
+}
+
+// celQuote returns s as a single-quoted CEL string literal.
+func celQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
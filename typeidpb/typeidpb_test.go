@@ -0,0 +1,272 @@
+package typeidpb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/typeidpb"
+)
+
+type userPrefix struct{}
+
+func (userPrefix) Prefix() string {
+	return "user"
+}
+
+type UserID = typeid.Sortable[userPrefix]
+
+type keyPrefix struct{}
+
+func (keyPrefix) Prefix() string {
+	return "key"
+}
+
+type KeyID = typeid.Random[keyPrefix]
+
+type optionalPrefix struct{}
+
+func (optionalPrefix) Prefix() string {
+	return "optional"
+}
+
+func (optionalPrefix) NilMarshaling() typeid.NilMarshaling {
+	return typeid.NilMarshalEmpty
+}
+
+type OptionalID = typeid.Random[optionalPrefix]
+
+type requiredPrefix struct{}
+
+func (requiredPrefix) Prefix() string {
+	return "required"
+}
+
+func (requiredPrefix) NilMarshaling() typeid.NilMarshaling {
+	return typeid.NilMarshalError
+}
+
+type RequiredID = typeid.Random[requiredPrefix]
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	id := typeid.MustNew[UserID]()
+	for _, tt := range []struct {
+		name        string
+		msg         *typeidpb.TypeID
+		expected    UserID
+		expectedErr error
+	}{
+		{
+			name:     "string",
+			msg:      typeid.Must(typeidpb.ToProto(id)),
+			expected: id,
+		},
+		{
+			name:     "string and uuid",
+			msg:      typeid.Must(typeidpb.ToProtoWithUUID(id)),
+			expected: id,
+		},
+		{
+			name:     "uuid",
+			msg:      &typeidpb.TypeID{Uuid: id.UUID().Bytes()},
+			expected: id,
+		},
+		{
+			name:        "nil",
+			msg:         nil,
+			expectedErr: typeid.ErrNilID,
+		},
+		{
+			name:        "empty",
+			msg:         &typeidpb.TypeID{},
+			expectedErr: typeid.ErrParse,
+		},
+		{
+			name:        "wrong prefix",
+			msg:         typeid.Must(typeidpb.ToProto(typeid.MustNew[KeyID]())),
+			expectedErr: typeid.ErrInvalidPrefix,
+		},
+		{
+			name:        "uuid mismatch",
+			msg:         &typeidpb.TypeID{Value: id.String(), Uuid: typeid.MustNew[UserID]().UUID().Bytes()},
+			expectedErr: typeidpb.ErrUUIDMismatch,
+		},
+	} {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := typeidpb.FromProto[UserID](tc.msg)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if parsed != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, parsed)
+			}
+		})
+	}
+}
+
+func TestFromProto_NilMarshalEmpty(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := typeidpb.FromProto[OptionalID](nil)
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if !parsed.IsNil() {
+		t.Errorf("expected the nil id, got %s", parsed)
+	}
+}
+
+func TestToProto_Nil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshal default", func(t *testing.T) {
+		t.Parallel()
+
+		for _, toProto := range []func(UserID) (*typeidpb.TypeID, error){typeidpb.ToProto[UserID], typeidpb.ToProtoWithUUID[UserID]} {
+			msg, err := toProto(typeid.Nil[UserID]())
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			parsed, err := typeidpb.FromProto[UserID](msg)
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if !parsed.IsNil() {
+				t.Errorf("expected the nil id, got %s", parsed)
+			}
+		}
+	})
+
+	t.Run("nil marshal empty", func(t *testing.T) {
+		t.Parallel()
+
+		for _, toProto := range []func(OptionalID) (*typeidpb.TypeID, error){typeidpb.ToProto[OptionalID], typeidpb.ToProtoWithUUID[OptionalID]} {
+			msg, err := toProto(typeid.Nil[OptionalID]())
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if msg != nil {
+				t.Errorf("expected an unset message, got %v", msg)
+			}
+			parsed, err := typeidpb.FromProto[OptionalID](msg)
+			if err != nil {
+				t.Fatalf("unexpected error:\n%+v", err)
+			}
+			if !parsed.IsNil() {
+				t.Errorf("expected the nil id, got %s", parsed)
+			}
+		}
+	})
+
+	t.Run("nil marshal error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, toProto := range []func(RequiredID) (*typeidpb.TypeID, error){typeidpb.ToProto[RequiredID], typeidpb.ToProtoWithUUID[RequiredID]} {
+			if _, err := toProto(typeid.Nil[RequiredID]()); !errors.Is(err, typeid.ErrNilID) {
+				t.Errorf("expected ErrNilID, got %v", err)
+			}
+		}
+	})
+}
+
+func TestMessageRule(t *testing.T) {
+	t.Parallel()
+
+	rule := typeidpb.MessageRule[UserID]()
+	if rule.ID != "typeid.user" {
+		t.Errorf("expected rule ID typeid.user, got %s", rule.ID)
+	}
+
+	id := typeid.MustNew[UserID]()
+	program := compile(t, rule.Expression, cel.ObjectType("sumup.typeid.v1.TypeID"))
+	for _, tt := range []struct {
+		name     string
+		msg      *typeidpb.TypeID
+		expected bool
+	}{
+		{name: "string", msg: typeid.Must(typeidpb.ToProto(id)), expected: true},
+		{name: "string and uuid", msg: typeid.Must(typeidpb.ToProtoWithUUID(id)), expected: true},
+		{name: "uuid", msg: &typeidpb.TypeID{Uuid: id.UUID().Bytes()}, expected: true},
+		{name: "empty", msg: &typeidpb.TypeID{}, expected: false},
+		{name: "wrong prefix", msg: typeid.Must(typeidpb.ToProto(typeid.MustNew[KeyID]())), expected: false},
+		{name: "short uuid", msg: &typeidpb.TypeID{Uuid: id.UUID().Bytes()[:8]}, expected: false},
+	} {
+		if valid := eval(t, program, tt.msg); valid != tt.expected {
+			t.Errorf("%s: expected %t, got %t", tt.name, tt.expected, valid)
+		}
+	}
+}
+
+func TestStringRule(t *testing.T) {
+	t.Parallel()
+
+	rule := typeidpb.StringRule[KeyID]()
+	program := compile(t, rule.Expression, cel.StringType)
+	for s, expected := range map[string]bool{
+		typeid.MustNew[KeyID]().String():  true,
+		typeid.MustNew[UserID]().String(): false,
+		"key_":                            false,
+	} {
+		if valid := eval(t, program, s); valid != expected {
+			t.Errorf("%s: expected %t, got %t", s, expected, valid)
+		}
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	t.Parallel()
+
+	expected := `(buf.validate.field).cel = {
+  id: "typeid.key"
+  message: "must be a valid key ID"
+  expression: "this.matches('^key_[0-7][0-9A-HJKMNP-TV-Z]{25}$')"
+}`
+	if s := typeidpb.StringRule[KeyID]().String(); s != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, s)
+	}
+}
+
+func compile(t *testing.T, expr string, typ *cel.Type) cel.Program {
+	t.Helper()
+
+	env, err := cel.NewEnv(cel.Types(&typeidpb.TypeID{}), cel.Variable("this", typ))
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		t.Fatalf("unexpected error:\n%+v", iss.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	return program
+}
+
+func eval(t *testing.T, program cel.Program, this any) bool {
+	t.Helper()
+
+	out, _, err := program.Eval(map[string]any{"this": this})
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	valid, ok := out.Value().(bool)
+	if !ok {
+		t.Fatalf("expected a bool, got %v", out)
+	}
+	return valid
+}
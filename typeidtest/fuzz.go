@@ -0,0 +1,89 @@
+package typeidtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sumup/typeid"
+)
+
+// suffixCorpus contains suffixes of valid and invalid IDs of all kinds.
+var suffixCorpus = []string{
+	// valid suffixes
+	"00000000000000000000000000",
+	"00000000000000000000000001",
+	"7zzzzzzzzzzzzzzzzzzzzzzzzz",
+	"7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+	"01hp1aybq6f6athhfcvp1j8fpt",
+	"01HP1AYBQ6F6ATHHFCVP1J8FPT",
+	"01HP1AYBQ6f6athhfcvp1j8fpt",
+	"0123456789abcdefghjkmnpqrs",
+	"0123456789ABCDEFGHJKMNPQRS",
+	// overflows
+	"8zzzzzzzzzzzzzzzzzzzzzzzzz",
+	"zzzzzzzzzzzzzzzzzzzzzzzzzz",
+	// invalid lengths
+	"",
+	"0",
+	"0000000000000000000000000",
+	"000000000000000000000000000",
+	// invalid characters
+	"0000000000000000000000000i",
+	"0000000000000000000000000l",
+	"0000000000000000000000000o",
+	"0000000000000000000000000u",
+	"0000000000000000000000000-",
+	"0000000000000000000000000\x00",
+	"0000000000000000000000000\xff",
+	"01hp1aybq6f6athhfcvp1j8fpT",
+}
+
+// Corpus returns a fuzz corpus for ID types with the given prefix. It contains valid and invalid IDs of all kinds,
+// IDs with invalid prefixes and malformed strings.
+func Corpus(prefix string) []string {
+	corpus := make([]string, 0, 3*len(suffixCorpus)+6)
+	for _, suffix := range suffixCorpus {
+		corpus = append(corpus,
+			prefix+"_"+suffix,
+			suffix,
+			"other_"+suffix,
+		)
+	}
+	return append(corpus,
+		prefix,
+		prefix+"_",
+		prefix+"__01hp1aybq6f6athhfcvp1j8fpt",
+		strings.ToUpper(prefix)+"_01hp1aybq6f6athhfcvp1j8fpt",
+		" "+prefix+"_01hp1aybq6f6athhfcvp1j8fpt",
+		prefix+"_01hp1aybq6f6athhfcvp1j8fpt ",
+	)
+}
+
+// Fuzz adds the [Corpus] for the prefix of the specified ID type to f and fuzzes parsing IDs of that type.
+// It checks that parsing never panics and that every successfully parsed ID round trips through its string representation.
+//
+// Example:
+//
+//	func FuzzUserID(f *testing.F) {
+//	    typeidtest.Fuzz[UserID](f)
+//	}
+func Fuzz[T typeid.IDType[P], P typeid.Prefix](f *testing.F) {
+	for _, s := range Corpus(typeid.Nil[T]().Type()) {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		parsed, err := typeid.FromString[T](s)
+		if err != nil {
+			return
+		}
+
+		reparsed, err := typeid.FromString[T](parsed.String())
+		if err != nil {
+			t.Fatalf("parsing the string representation of %q failed: %v", s, err)
+		}
+		if parsed != reparsed {
+			t.Errorf("round trip of %q resulted in a different id: %s != %s", s, parsed, reparsed)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+// Package typeidtest provides helpers for testing code that uses ID types of [github.com/sumup/typeid]:
+// deterministic ID generators, [github.com/google/go-cmp/cmp] options, assertions and a fuzz corpus.
+package typeidtest
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sumup/typeid"
+)
+
+// versionSharded is the UUID version of [typeid.Sharded] IDs.
+const versionSharded = 8
+
+// Seq returns a generator of sequential IDs of the specified type. The first call returns the ID with UUID 1,
+// e.g. user_00000000000000000000000001, the second one the ID with UUID 2, and so on.
+// For [typeid.Sharded] IDs, the UUIDs carry the version 8 required by the kind and are in shard 0.
+// The generator is safe for concurrent use.
+//
+// Example:
+//
+//	next := typeidtest.Seq[UserID]()
+//	first, second := next(), next()
+func Seq[T typeid.IDType[P], P typeid.Prefix]() func() T {
+	var counter atomic.Uint64
+	sharded := typeid.KindOf[T]() == typeid.KindSharded
+	return func() T {
+		var u uuid.UUID
+		binary.BigEndian.PutUint64(u[8:], counter.Add(1))
+		if sharded {
+			u.SetVersion(versionSharded)
+			u.SetVariant(uuid.VariantRFC9562)
+		}
+		return typeid.Must(typeid.FromUUID[T](u))
+	}
+}
+
+// SortableAt returns a generator of UUIDv7 based IDs of the specified type, all created at the given time.
+// Instead of random bits, the IDs contain a sequence number, so that the generated IDs are deterministic
+// and sort in the order they were generated. [typeid.Sharded] IDs are based on UUIDv8 instead and are in shard 0.
+// The generator is safe for concurrent use.
+//
+// Example:
+//
+//	next := typeidtest.SortableAt[OrderID](time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	orderID := next()
+func SortableAt[T typeid.IDType[P], P typeid.Prefix](t time.Time) func() T {
+	var counter atomic.Uint64
+	ms := uint64(t.UnixMilli())
+	version := byte(uuid.V7)
+	if typeid.KindOf[T]() == typeid.KindSharded {
+		version = versionSharded
+	}
+	return func() T {
+		var u uuid.UUID
+		// The 16 bits following the timestamp hold the version and, for sharded IDs, the shard.
+		binary.BigEndian.PutUint64(u[:8], ms<<16)
+		binary.BigEndian.PutUint64(u[8:], counter.Add(1))
+		u.SetVersion(version)
+		u.SetVariant(uuid.VariantRFC9562)
+		return typeid.Must(typeid.FromUUID[T](u))
+	}
+}
+
+// EquateIDs returns a [cmp.Option] that compares IDs of the typeid package by their type, UUID and environment,
+// as [cmp.Diff] and [cmp.Equal] otherwise fail on the unexported fields of the ID types.
+//
+// Example:
+//
+//	if diff := cmp.Diff(want, got, typeidtest.EquateIDs()); diff != "" {
+//	    t.Errorf("mismatch (-want +got):\n%s", diff)
+//	}
+func EquateIDs() cmp.Option {
+	return cmp.Comparer(func(a, b typeid.ID) bool {
+		// The ID types are comparable, == compares both the dynamic types and the values.
+		return a == b
+	})
+}
+
+// AssertValid asserts that the given ID is not the nil ID and that its string representation parses back to the same ID.
+func AssertValid[T typeid.IDType[P], P typeid.Prefix](tb testing.TB, tid T) {
+	tb.Helper()
+
+	if tid.IsNil() {
+		tb.Errorf("expected a valid %T, got the nil id %s", tid, tid)
+		return
+	}
+
+	parsed, err := typeid.FromString[T](tid.String())
+	if err != nil {
+		tb.Errorf("expected a valid %T, parsing %s failed: %v", tid, tid, err)
+		return
+	}
+	if tid != parsed {
+		tb.Errorf("expected a valid %T, %s parses to %s", tid, tid, parsed)
+	}
+}
+
+// AssertPrefix asserts that the given ID has exactly the given prefix. The ID is parsed with [typeid.Inspect],
+// the environment qualifier of IDs with an [typeid.EnvironmentQualifier] is not part of the prefix.
+func AssertPrefix(tb testing.TB, tid typeid.ID, prefix string) {
+	tb.Helper()
+
+	s := tid.String()
+	info, err := typeid.Inspect(s)
+	if err != nil {
+		tb.Errorf("expected prefix %q, parsing %s failed: %v", prefix, s, err)
+		return
+	}
+
+	actual := info.Prefix
+	if e, ok := tid.(interface{ Environment() string }); ok && e.Environment() != "" {
+		qualified := e.Environment()
+		if tid.Type() != "" {
+			qualified = tid.Type() + "_" + qualified
+		}
+		if actual == qualified {
+			actual = tid.Type()
+		}
+	}
+	if actual != prefix {
+		tb.Errorf("expected prefix %q, got %s", prefix, s)
+	}
+}
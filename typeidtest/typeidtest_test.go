@@ -0,0 +1,210 @@
+package typeidtest_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sumup/typeid"
+	"github.com/sumup/typeid/typeidtest"
+)
+
+type userPrefix struct{}
+
+func (userPrefix) Prefix() string {
+	return "user"
+}
+
+type orderPrefix struct{}
+
+func (orderPrefix) Prefix() string {
+	return "order"
+}
+
+type customerPrefix struct{}
+
+func (customerPrefix) Prefix() string {
+	return "customer"
+}
+
+func (customerPrefix) Environments() []string {
+	return []string{"live", "test"}
+}
+
+type shipmentPrefix struct{}
+
+func (shipmentPrefix) Prefix() string {
+	return "shipment"
+}
+
+type noPrefix struct{}
+
+func (noPrefix) Prefix() string {
+	return ""
+}
+
+type (
+	UserID     = typeid.Random[userPrefix]
+	OrderID    = typeid.Sortable[orderPrefix]
+	CustomerID = typeid.Sortable[customerPrefix]
+	AnonID     = typeid.Random[noPrefix]
+	ShipmentID = typeid.Sharded[shipmentPrefix]
+)
+
+func TestSeq(t *testing.T) {
+	t.Parallel()
+
+	next := typeidtest.Seq[UserID]()
+	for _, expected := range []string{
+		"user_00000000000000000000000001",
+		"user_00000000000000000000000002",
+		"user_00000000000000000000000003",
+	} {
+		if id := next(); expected != id.String() {
+			t.Errorf("expected %s, got %s", expected, id)
+		}
+	}
+
+	// Generators are independent of each other.
+	if id := typeidtest.Seq[OrderID](); "order_00000000000000000000000001" != id().String() {
+		t.Errorf("expected order_00000000000000000000000001, got %s", id())
+	}
+}
+
+func TestSortableAt(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 2, 7, 8, 28, 55, 398000000, time.UTC)
+	next := typeidtest.SortableAt[OrderID](at)
+
+	first, second := next(), next()
+	if "order_01hp1aybq6e008000000000001" != first.String() {
+		t.Errorf("unexpected first id %s", first)
+	}
+	if first.Compare(second) >= 0 {
+		t.Errorf("ids must sort in the order they were generated: %s >= %s", first, second)
+	}
+
+	ts, err := uuid.TimestampFromV7(first.UUID())
+	if err != nil {
+		t.Fatalf("unexpected error:\n%+v", err)
+	}
+	if created, _ := ts.Time(); !at.Equal(created) {
+		t.Errorf("expected time %s, got %s", at, created)
+	}
+
+	typeidtest.AssertValid(t, first)
+}
+
+func TestShardedIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("seq", func(t *testing.T) {
+		t.Parallel()
+
+		next := typeidtest.Seq[ShipmentID]()
+		first, second := next(), next()
+		if first.Compare(second) >= 0 {
+			t.Errorf("ids must sort in the order they were generated: %s >= %s", first, second)
+		}
+		if shard := typeid.ShardOf(first); shard != 0 {
+			t.Errorf("expected shard 0, got %d", shard)
+		}
+		typeidtest.AssertValid(t, first)
+	})
+
+	t.Run("sortable at", func(t *testing.T) {
+		t.Parallel()
+
+		at := time.Date(2024, 2, 7, 8, 28, 55, 398000000, time.UTC)
+		first := typeidtest.SortableAt[ShipmentID](at)()
+		info, err := typeid.Inspect(first.String())
+		if err != nil {
+			t.Fatalf("unexpected error:\n%+v", err)
+		}
+		if info.Kind != typeid.KindSharded || !at.Equal(info.Time) {
+			t.Errorf("expected a sharded id created at %s, got %+v", at, info)
+		}
+		if shard := typeid.ShardOf(first); shard != 0 {
+			t.Errorf("expected shard 0, got %d", shard)
+		}
+		typeidtest.AssertValid(t, first)
+	})
+}
+
+func TestEquateIDs(t *testing.T) {
+	t.Parallel()
+
+	type entity struct {
+		ID     UserID
+		Orders []OrderID
+	}
+
+	nextOrder := typeidtest.Seq[OrderID]()
+	a := entity{ID: typeid.MustNew[UserID](), Orders: []OrderID{nextOrder(), nextOrder()}}
+	b := a
+	if diff := cmp.Diff(a, b, typeidtest.EquateIDs()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	b.Orders = []OrderID{a.Orders[0], nextOrder()}
+	if cmp.Equal(a, b, typeidtest.EquateIDs()) {
+		t.Errorf("entities with different ids must not be equal")
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	typeidtest.AssertValid(t, typeid.MustNew[UserID]())
+	typeidtest.AssertPrefix(t, typeid.MustNew[OrderID](), "order")
+
+	mock := &mockTB{TB: t}
+	typeidtest.AssertValid(mock, typeid.Nil[UserID]())
+	if !mock.failed {
+		t.Errorf("AssertValid must fail for nil ids")
+	}
+
+	mock = &mockTB{TB: t}
+	typeidtest.AssertPrefix(mock, typeid.MustNew[UserID](), "order")
+	if !mock.failed {
+		t.Errorf("AssertPrefix must fail for other prefixes")
+	}
+
+	typeidtest.AssertPrefix(t, typeid.MustNew[AnonID](), "")
+	mock = &mockTB{TB: t}
+	typeidtest.AssertPrefix(mock, typeid.MustNew[UserID](), "")
+	if !mock.failed {
+		t.Errorf("AssertPrefix must fail for a prefixed id and the empty prefix")
+	}
+
+	suffix := strings.TrimPrefix(typeid.MustNew[CustomerID]().String(), "customer_")
+	testID := typeid.Must(typeid.FromString[CustomerID]("customer_test_" + suffix))
+	typeidtest.AssertPrefix(t, testID, "customer")
+	mock = &mockTB{TB: t}
+	typeidtest.AssertPrefix(mock, testID, "customer_test")
+	if !mock.failed {
+		t.Errorf("AssertPrefix must not treat the environment qualifier as part of the prefix")
+	}
+}
+
+// mockTB records failures instead of failing the test.
+type mockTB struct {
+	testing.TB
+	failed bool
+}
+
+func (m *mockTB) Errorf(string, ...any) {
+	m.failed = true
+}
+
+func FuzzUserID(f *testing.F) {
+	typeidtest.Fuzz[UserID](f)
+}
+
+func FuzzOrderID(f *testing.F) {
+	typeidtest.Fuzz[OrderID](f)
+}